@@ -19,6 +19,7 @@ package admission
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
@@ -31,11 +32,15 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	admissionapi "k8s.io/pod-security-admission/admission/api"
 	"k8s.io/pod-security-admission/admission/api/validation"
 	"k8s.io/pod-security-admission/api"
@@ -59,19 +64,36 @@ type Admission struct {
 	// Metrics
 	Metrics metrics.Recorder
 
+	// ViolationSink is optional, and receives a record of every enforce, audit and warn violation.
+	ViolationSink ViolationSink
+
 	// Arbitrary object --> PodSpec
 	PodSpecExtractor PodSpecExtractor
 
 	// API connections
 	NamespaceGetter NamespaceGetter
 	PodLister       PodLister
+	// PodControllerLister is optional, and only used by DryRunNamespacePolicy.
+	PodControllerLister PodControllerLister
 
+	// levels holds the built-in levels and the custom levels of the configuration.
+	levels        *api.LevelSet
 	defaultPolicy api.Policy
 
+	namespaceExemptionSelector labels.Selector
+	checkExemptions            []checkExemption
+
 	namespaceMaxPodsToCheck  int
 	namespacePodCheckTimeout time.Duration
 }
 
+// checkExemption is the parsed form of an admissionapi.PodSecurityCheckExemption.
+type checkExemption struct {
+	checkIDs          sets.Set[string]
+	namespaceSelector labels.Selector
+	podSelector       labels.Selector
+}
+
 type NamespaceGetter interface {
 	GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error)
 }
@@ -152,11 +174,29 @@ func extractPodSpecFromTemplate(template *corev1.PodTemplateSpec) (*metav1.Objec
 // CompleteConfiguration sets up default or derived configuration.
 func (a *Admission) CompleteConfiguration() error {
 	if a.Configuration != nil {
-		if p, err := admissionapi.ToPolicy(a.Configuration.Defaults); err != nil {
+		// Custom levels must be ordered before the levels of the defaults are parsed.
+		if levels, err := api.NewLevelSet(admissionapi.ToCustomLevels(a.Configuration.Levels)); err != nil {
+			return fmt.Errorf("invalid custom levels: %w", err)
+		} else {
+			a.levels = levels
+		}
+		if p, err := admissionapi.ToPolicyWithLevels(a.Configuration.Defaults, a.levels); err != nil {
 			return err
 		} else {
 			a.defaultPolicy = p
 		}
+		if selector := a.Configuration.Exemptions.NamespaceSelector; selector != nil {
+			s, err := metav1.LabelSelectorAsSelector(selector)
+			if err != nil {
+				return fmt.Errorf("invalid namespaceSelector exemption: %w", err)
+			}
+			a.namespaceExemptionSelector = s
+		}
+		if exemptions, err := parseCheckExemptions(a.Configuration.Exemptions.Checks); err != nil {
+			return err
+		} else {
+			a.checkExemptions = exemptions
+		}
 	}
 	a.namespaceMaxPodsToCheck = defaultNamespaceMaxPodsToCheck
 	a.namespacePodCheckTimeout = defaultNamespacePodCheckTimeout
@@ -175,10 +215,16 @@ func (a *Admission) ValidateConfiguration() error {
 	} else if errs := validation.ValidatePodSecurityConfiguration(a.Configuration); len(errs) > 0 {
 		return errs.ToAggregate()
 	} else {
-		if p, err := admissionapi.ToPolicy(a.Configuration.Defaults); err != nil {
+		if a.levels == nil {
+			return fmt.Errorf("custom levels not parsed; CompleteConfiguration() was not called before ValidateConfiguration()")
+		} else if p, err := admissionapi.ToPolicyWithLevels(a.Configuration.Defaults, a.levels); err != nil {
 			return err
 		} else if !reflect.DeepEqual(p, a.defaultPolicy) {
 			return fmt.Errorf("default policy does not match; CompleteConfiguration() was not called before ValidateConfiguration()")
+		} else if (a.namespaceExemptionSelector == nil) != (a.Configuration.Exemptions.NamespaceSelector == nil) {
+			return fmt.Errorf("namespace selector exemption not parsed; CompleteConfiguration() was not called before ValidateConfiguration()")
+		} else if len(a.checkExemptions) != len(a.Configuration.Exemptions.Checks) {
+			return fmt.Errorf("check exemptions not parsed; CompleteConfiguration() was not called before ValidateConfiguration()")
 		}
 	}
 	if a.namespaceMaxPodsToCheck == 0 || a.namespacePodCheckTimeout == 0 {
@@ -250,7 +296,7 @@ func (a *Admission) ValidateNamespace(ctx context.Context, attrs api.Attributes)
 		if len(newErrs) > 0 {
 			return invalidResponse(attrs, newErrs)
 		}
-		if a.exemptNamespace(attrs.GetNamespace()) {
+		if a.exemptNamespace(attrs.GetNamespace()) || a.exemptNamespaceLabels(namespace.Labels) {
 			if warning := a.exemptNamespaceWarning(namespace.Name, newPolicy, namespace.Labels); warning != "" {
 				response := allowedResponse()
 				response.Warnings = append(response.Warnings, warning)
@@ -290,10 +336,10 @@ func (a *Admission) ValidateNamespace(ctx context.Context, attrs api.Attributes)
 			return sharedAllowedResponse
 		}
 		if newPolicy.Enforce.Version == oldPolicy.Enforce.Version &&
-			api.CompareLevels(newPolicy.Enforce.Level, oldPolicy.Enforce.Level) < 1 {
+			a.levels.CompareLevels(newPolicy.Enforce.Level, oldPolicy.Enforce.Level) < 1 {
 			return sharedAllowedResponse
 		}
-		if a.exemptNamespace(attrs.GetNamespace()) {
+		if a.exemptNamespace(attrs.GetNamespace()) || a.exemptNamespaceLabels(namespace.Labels) {
 			if warning := a.exemptNamespaceWarning(namespace.Name, newPolicy, namespace.Labels); warning != "" {
 				response := allowedResponse()
 				response.Warnings = append(response.Warnings, warning)
@@ -302,7 +348,7 @@ func (a *Admission) ValidateNamespace(ctx context.Context, attrs api.Attributes)
 			return sharedAllowedResponse
 		}
 		response := allowedResponse()
-		response.Warnings = a.EvaluatePodsInNamespace(ctx, namespace.Name, newPolicy.Enforce)
+		response.Warnings = a.evaluatePodsInNamespace(ctx, namespace.Name, namespace.Labels, newPolicy.Enforce)
 		return response
 
 	default:
@@ -337,7 +383,7 @@ func (a *Admission) ValidatePod(ctx context.Context, attrs api.Attributes) *admi
 		return sharedAllowedByNamespaceExemptionResponse
 	}
 
-	if a.exemptUser(attrs.GetUserName()) {
+	if a.exemptUser(attrs.GetUserName(), attrs.GetUserGroups()) {
 		a.Metrics.RecordExemption(attrs)
 		return sharedAllowedByUserExemptionResponse
 	}
@@ -349,6 +395,10 @@ func (a *Admission) ValidatePod(ctx context.Context, attrs api.Attributes) *admi
 		a.Metrics.RecordError(true, attrs)
 		return errorResponse(err, &apierrors.NewInternalError(fmt.Errorf("failed to lookup namespace %q", attrs.GetNamespace())).ErrStatus)
 	}
+	if a.exemptNamespaceLabels(namespace.Labels) {
+		a.Metrics.RecordExemption(attrs)
+		return sharedAllowedByNamespaceExemptionResponse
+	}
 	nsPolicy, nsPolicyErrs := a.PolicyToEvaluate(namespace.Labels)
 	if len(nsPolicyErrs) == 0 && nsPolicy.FullyPrivileged() {
 		a.Metrics.RecordEvaluation(metrics.DecisionAllow, nsPolicy.Enforce, metrics.ModeEnforce, attrs)
@@ -385,7 +435,7 @@ func (a *Admission) ValidatePod(ctx context.Context, attrs api.Attributes) *admi
 			return sharedAllowedResponse
 		}
 	}
-	return a.EvaluatePod(ctx, nsPolicy, nsPolicyErrs.ToAggregate(), &pod.ObjectMeta, &pod.Spec, attrs, true)
+	return a.evaluatePod(ctx, nsPolicy, nsPolicyErrs.ToAggregate(), namespace.Labels, &pod.ObjectMeta, &pod.Spec, attrs, true)
 }
 
 // ValidatePodController evaluates a pod controller create or update request against the effective policy for the namespace.
@@ -401,7 +451,7 @@ func (a *Admission) ValidatePodController(ctx context.Context, attrs api.Attribu
 		return sharedAllowedByNamespaceExemptionResponse
 	}
 
-	if a.exemptUser(attrs.GetUserName()) {
+	if a.exemptUser(attrs.GetUserName(), attrs.GetUserGroups()) {
 		a.Metrics.RecordExemption(attrs)
 		return sharedAllowedByUserExemptionResponse
 	}
@@ -417,6 +467,10 @@ func (a *Admission) ValidatePodController(ctx context.Context, attrs api.Attribu
 		}
 		return response
 	}
+	if a.exemptNamespaceLabels(namespace.Labels) {
+		a.Metrics.RecordExemption(attrs)
+		return sharedAllowedByNamespaceExemptionResponse
+	}
 	nsPolicy, nsPolicyErrs := a.PolicyToEvaluate(namespace.Labels)
 	if len(nsPolicyErrs) == 0 && nsPolicy.Warn.Level == api.LevelPrivileged && nsPolicy.Audit.Level == api.LevelPrivileged {
 		return sharedAllowedResponse
@@ -446,13 +500,21 @@ func (a *Admission) ValidatePodController(ctx context.Context, attrs api.Attribu
 		// if a controller with an optional pod spec does not contain a pod spec, skip validation
 		return sharedAllowedResponse
 	}
-	return a.EvaluatePod(ctx, nsPolicy, nsPolicyErrs.ToAggregate(), podMetadata, podSpec, attrs, false)
+	return a.evaluatePod(ctx, nsPolicy, nsPolicyErrs.ToAggregate(), namespace.Labels, podMetadata, podSpec, attrs, false)
 }
 
 // EvaluatePod evaluates the given policy against the given pod(-like) object.
 // The enforce policy is only checked if enforce=true.
 // The returned response may be shared between evaluations and must not be mutated.
 func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPolicyErr error, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, attrs api.Attributes, enforce bool) *admissionv1.AdmissionResponse {
+	nsLabels := a.namespaceLabelsForExemptions(ctx, attrs.GetNamespace())
+	return a.evaluatePod(ctx, nsPolicy, nsPolicyErr, nsLabels, podMetadata, podSpec, attrs, enforce)
+}
+
+// evaluatePod is EvaluatePod with the labels of the namespace, which are matched against the namespace
+// selectors of the check exemptions. Checks waived by a check exemption matching nsLabels and the pod labels
+// are not evaluated.
+func (a *Admission) evaluatePod(ctx context.Context, nsPolicy api.Policy, nsPolicyErr error, nsLabels map[string]string, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, attrs api.Attributes, enforce bool) *admissionv1.AdmissionResponse {
 	logger := klog.FromContext(ctx)
 	// short-circuit on exempt runtimeclass
 	if a.exemptRuntimeClass(podSpec.RuntimeClassName) {
@@ -467,6 +529,18 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 		a.Metrics.RecordError(false, attrs)
 	}
 
+	var podLabels map[string]string
+	if podMetadata != nil {
+		podLabels = podMetadata.Labels
+	}
+	exemptChecks := a.exemptChecks(nsLabels, podLabels)
+	if exemptChecks.Len() > 0 {
+		auditAnnotations[api.ExemptChecksAnnotationKey] = strings.Join(sets.List(exemptChecks), ",")
+	}
+	evaluate := func(lv api.LevelVersion, opts ...policy.Option) policy.AggregateCheckResult {
+		return policy.AggregateCheckResults(withoutExemptChecks(a.Evaluator.EvaluatePod(lv, podMetadata, podSpec, opts...), exemptChecks))
+	}
+
 	if klogV := logger.V(5); klogV.Enabled() {
 		klogV.Info("PodSecurity evaluation", "policy", fmt.Sprintf("%v", nsPolicy), "op", attrs.GetOperation(), "resource", attrs.GetResource(), "namespace", attrs.GetNamespace(), "name", attrs.GetName())
 	}
@@ -475,14 +549,15 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 	if enforce {
 		auditAnnotations[api.EnforcedPolicyAnnotationKey] = nsPolicy.Enforce.String()
 
-		result := policy.AggregateCheckResults(a.Evaluator.EvaluatePod(nsPolicy.Enforce, podMetadata, podSpec))
+		result := evaluate(nsPolicy.Enforce, policy.WithFieldErrors())
 		if !result.Allowed {
 			response = forbiddenResponse(attrs, fmt.Errorf(
 				"violates PodSecurity %q: %s",
 				nsPolicy.Enforce.String(),
 				result.ForbiddenDetail(),
-			))
+			), violationCauses(&result)...)
 			a.Metrics.RecordEvaluation(metrics.DecisionDeny, nsPolicy.Enforce, metrics.ModeEnforce, attrs)
+			a.recordViolation(ctx, metrics.ModeEnforce, nsPolicy.Enforce, &result, attrs)
 		} else {
 			a.Metrics.RecordEvaluation(metrics.DecisionAllow, nsPolicy.Enforce, metrics.ModeEnforce, attrs)
 		}
@@ -493,7 +568,7 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 
 	auditResult, ok := cachedResults[nsPolicy.Audit]
 	if !ok {
-		auditResult = policy.AggregateCheckResults(a.Evaluator.EvaluatePod(nsPolicy.Audit, podMetadata, podSpec))
+		auditResult = evaluate(nsPolicy.Audit)
 		cachedResults[nsPolicy.Audit] = auditResult
 	}
 	if !auditResult.Allowed {
@@ -503,6 +578,7 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 			auditResult.ForbiddenDetail(),
 		)
 		a.Metrics.RecordEvaluation(metrics.DecisionDeny, nsPolicy.Audit, metrics.ModeAudit, attrs)
+		a.recordViolation(ctx, metrics.ModeAudit, nsPolicy.Audit, &auditResult, attrs)
 	}
 
 	// avoid adding warnings to a request we're already going to reject with an error
@@ -510,7 +586,7 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 		// reuse previous evaluation if warn level+version is the same as audit or enforce level+version
 		warnResult, ok := cachedResults[nsPolicy.Warn]
 		if !ok {
-			warnResult = policy.AggregateCheckResults(a.Evaluator.EvaluatePod(nsPolicy.Warn, podMetadata, podSpec))
+			warnResult = evaluate(nsPolicy.Warn)
 		}
 		if !warnResult.Allowed {
 			// TODO: Craft a better user-facing warning message
@@ -520,6 +596,7 @@ func (a *Admission) EvaluatePod(ctx context.Context, nsPolicy api.Policy, nsPoli
 				warnResult.ForbiddenDetail(),
 			))
 			a.Metrics.RecordEvaluation(metrics.DecisionDeny, nsPolicy.Warn, metrics.ModeWarn, attrs)
+			a.recordViolation(ctx, metrics.ModeWarn, nsPolicy.Warn, &warnResult, attrs)
 		}
 	}
 
@@ -537,6 +614,13 @@ type podCount struct {
 }
 
 func (a *Admission) EvaluatePodsInNamespace(ctx context.Context, namespace string, enforce api.LevelVersion) []string {
+	nsLabels := a.namespaceLabelsForExemptions(ctx, namespace)
+	return a.evaluatePodsInNamespace(ctx, namespace, nsLabels, enforce)
+}
+
+// evaluatePodsInNamespace is EvaluatePodsInNamespace with the labels of the namespace, which may be about to
+// change. Checks waived by a check exemption matching nsLabels and the pod labels are not evaluated.
+func (a *Admission) evaluatePodsInNamespace(ctx context.Context, namespace string, nsLabels map[string]string, enforce api.LevelVersion) []string {
 	// start with the default timeout
 	timeout := a.namespacePodCheckTimeout
 	if deadline, ok := ctx.Deadline(); ok {
@@ -570,7 +654,8 @@ func (a *Admission) EvaluatePodsInNamespace(ctx context.Context, namespace strin
 
 	checkedPods := len(prioritizedPods)
 	for i, pod := range prioritizedPods {
-		r := policy.AggregateCheckResults(a.Evaluator.EvaluatePod(enforce, &pod.ObjectMeta, &pod.Spec))
+		results := a.Evaluator.EvaluatePod(enforce, &pod.ObjectMeta, &pod.Spec)
+		r := policy.AggregateCheckResults(withoutExemptChecks(results, a.exemptChecks(nsLabels, pod.Labels)))
 		if !r.Allowed {
 			warning := r.ForbiddenReason()
 			c, seen := podWarningsToCount[warning]
@@ -623,7 +708,12 @@ func decoratePodWarnings(podWarningsToCount map[string]podCount, warnings []stri
 }
 
 func (a *Admission) PolicyToEvaluate(labels map[string]string) (api.Policy, field.ErrorList) {
-	return api.PolicyToEvaluate(labels, a.defaultPolicy)
+	return a.levels.PolicyToEvaluate(labels, a.defaultPolicy)
+}
+
+// Levels returns the valid levels of the configuration, built-in and custom, ordered by strictness.
+func (a *Admission) Levels() *api.LevelSet {
+	return a.levels
 }
 
 // isSignificantPodUpdate determines whether a pod update should trigger a policy evaluation.
@@ -675,14 +765,28 @@ func (a *Admission) exemptNamespace(namespace string) bool {
 		return false
 	}
 	// TODO: consider optimizing to O(1) lookup
-	return containsString(namespace, a.Configuration.Exemptions.Namespaces)
+	return containsString(namespace, a.Configuration.Exemptions.Namespaces) ||
+		matchesPattern(namespace, a.Configuration.Exemptions.NamespacePatterns)
+}
+
+// exemptNamespaceLabels returns true if the namespace labels match the namespace selector exemption.
+func (a *Admission) exemptNamespaceLabels(nsLabels map[string]string) bool {
+	return a.namespaceExemptionSelector != nil && a.namespaceExemptionSelector.Matches(labels.Set(nsLabels))
 }
-func (a *Admission) exemptUser(username string) bool {
+func (a *Admission) exemptUser(username string, groups []string) bool {
 	if len(username) == 0 {
 		return false
 	}
 	// TODO: consider optimizing to O(1) lookup
-	return containsString(username, a.Configuration.Exemptions.Usernames)
+	if containsString(username, a.Configuration.Exemptions.Usernames) {
+		return true
+	}
+	for _, group := range groups {
+		if containsString(group, a.Configuration.Exemptions.Groups) {
+			return true
+		}
+	}
+	return matchesServiceAccount(username, a.Configuration.Exemptions.ServiceAccounts)
 }
 func (a *Admission) exemptRuntimeClass(runtimeClass *string) bool {
 	if runtimeClass == nil || len(*runtimeClass) == 0 {
@@ -692,6 +796,169 @@ func (a *Admission) exemptRuntimeClass(runtimeClass *string) bool {
 	return containsString(*runtimeClass, a.Configuration.Exemptions.RuntimeClasses)
 }
 
+// exemptChecks returns the IDs of the checks waived for a pod with the given labels in a namespace with the given labels.
+func (a *Admission) exemptChecks(nsLabels, podLabels map[string]string) sets.Set[string] {
+	var checkIDs sets.Set[string]
+	for _, exemption := range a.checkExemptions {
+		if !exemption.namespaceSelector.Matches(labels.Set(nsLabels)) || !exemption.podSelector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		if checkIDs == nil {
+			checkIDs = sets.New[string]()
+		}
+		checkIDs = checkIDs.Union(exemption.checkIDs)
+	}
+	return checkIDs
+}
+
+// namespaceLabelsForExemptions returns the labels of the namespace if a check exemption selects namespaces
+// by label, for callers that did not get the namespace.
+func (a *Admission) namespaceLabelsForExemptions(ctx context.Context, name string) map[string]string {
+	selectsNamespaces := false
+	for _, exemption := range a.checkExemptions {
+		selectsNamespaces = selectsNamespaces || !exemption.namespaceSelector.Empty()
+	}
+	if !selectsNamespaces || name == "" {
+		return nil
+	}
+	namespace, err := a.NamespaceGetter.GetNamespace(ctx, name)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to fetch namespace for check exemptions", "namespace", name)
+		return nil
+	}
+	return namespace.Labels
+}
+
+// withoutExemptChecks drops the results of exempt checks.
+func withoutExemptChecks(results []policy.CheckResult, exemptChecks sets.Set[string]) []policy.CheckResult {
+	if exemptChecks.Len() == 0 {
+		return results
+	}
+	filtered := make([]policy.CheckResult, 0, len(results))
+	for _, result := range results {
+		if !exemptChecks.Has(string(result.CheckID)) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// EvaluatorOptions returns the options to construct the Evaluator with, to evaluate the custom levels,
+// checks and allow-lists declared in the configuration.
+func EvaluatorOptions(config *admissionapi.PodSecurityConfiguration) []policy.EvaluatorOption {
+	var levels []policy.CustomLevel
+	for _, l := range config.Levels {
+		level := policy.CustomLevel{
+			Name: api.Level(l.Name),
+			Base: api.Level(l.Base),
+		}
+		for _, id := range l.AddChecks {
+			level.AddChecks = append(level.AddChecks, policy.CheckID(id))
+		}
+		for _, id := range l.RemoveChecks {
+			level.RemoveChecks = append(level.RemoveChecks, policy.CheckID(id))
+		}
+		levels = append(levels, level)
+	}
+
+	var celChecks []policy.CELCheck
+	for _, c := range config.Checks.CEL {
+		// Invalid versions are parsed as latest, which the Evaluator rejects.
+		minimumVersion, _ := api.ParseVersion(c.MinimumVersion)
+		celChecks = append(celChecks, policy.CELCheck{
+			ID:                policy.CheckID(c.ID),
+			Level:             api.Level(c.Level),
+			MinimumVersion:    minimumVersion,
+			Expression:        c.Expression,
+			Reason:            c.Reason,
+			MessageExpression: c.MessageExpression,
+			FieldPath:         c.FieldPath,
+		})
+	}
+
+	var checks []policy.Check
+	if c := config.Checks.Images; c != nil {
+		checks = append(checks, policy.CheckImages(policy.ImagePolicy{
+			Level:             api.Level(c.Level),
+			AllowedRegistries: c.AllowedRegistries,
+			DisallowedTags:    c.DisallowedTags,
+			RequireDigest:     c.RequireDigest,
+		}))
+	}
+	if c := config.Checks.Resources; c != nil {
+		checks = append(checks, policy.CheckResources(policy.ResourcePolicy{
+			Level:   api.Level(c.Level),
+			Minimum: resourceList(c.Minimum),
+			Maximum: resourceList(c.Maximum),
+		}))
+	}
+
+	hardened := sets.New(config.Checks.Hardened...)
+	for _, check := range policy.HardenedChecks() {
+		if hardened.Has(string(check.ID)) {
+			checks = append(checks, check)
+		}
+	}
+
+	var allowLists []policy.LevelAllowLists
+	for _, l := range config.AllowLists {
+		allowLists = append(allowLists, policy.LevelAllowLists{
+			Level:        api.Level(l.Level),
+			Sysctls:      policy.AllowListOverride{Add: l.Sysctls.Add, Remove: l.Sysctls.Remove},
+			Capabilities: policy.AllowListOverride{Add: l.Capabilities.Add, Remove: l.Capabilities.Remove},
+			VolumeTypes:  policy.AllowListOverride{Add: l.VolumeTypes.Add, Remove: l.VolumeTypes.Remove},
+		})
+	}
+
+	return []policy.EvaluatorOption{
+		policy.WithCustomLevels(levels...),
+		policy.WithChecks(checks...),
+		policy.WithCELChecks(celChecks...),
+		policy.WithAllowLists(allowLists...),
+	}
+}
+
+// resourceList converts configured quantities to a ResourceList.
+// Invalid quantities are rejected by validation, and skipped here.
+func resourceList(quantities map[string]string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	for name, value := range quantities {
+		if quantity, err := resource.ParseQuantity(value); err == nil {
+			list[corev1.ResourceName(name)] = quantity
+		}
+	}
+	return list
+}
+
+// parseCheckExemptions converts the configured check exemptions to selectors.
+// An unset selector matches everything.
+func parseCheckExemptions(exemptions []admissionapi.PodSecurityCheckExemption) ([]checkExemption, error) {
+	parsed := make([]checkExemption, 0, len(exemptions))
+	for i, exemption := range exemptions {
+		e := checkExemption{
+			checkIDs:          sets.New(exemption.CheckIDs...),
+			namespaceSelector: labels.Everything(),
+			podSelector:       labels.Everything(),
+		}
+		if exemption.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(exemption.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespaceSelector in check exemption %d: %w", i, err)
+			}
+			e.namespaceSelector = selector
+		}
+		if exemption.PodSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(exemption.PodSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid podSelector in check exemption %d: %w", i, err)
+			}
+			e.podSelector = selector
+		}
+		parsed = append(parsed, e)
+	}
+	return parsed, nil
+}
+
 // Filter and prioritize pods based on runtimeclass and uniqueness of the controller respectively for evaluation.
 // The input slice is modified in place and should not be reused.
 func (a *Admission) prioritizePods(pods []*corev1.Pod) []*corev1.Pod {
@@ -730,6 +997,40 @@ func containsString(needle string, haystack []string) bool {
 	return false
 }
 
+// matchesServiceAccount returns true if username is a service account matching any of the
+// "system:serviceaccount:<namespace>:<name>" patterns.
+// Malformed patterns are rejected by validation and never match.
+func matchesServiceAccount(username string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	namespace, name, err := serviceaccount.SplitUsername(username)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range patterns {
+		namespacePattern, namePattern, err := admissionapi.SplitServiceAccountPattern(pattern)
+		if err != nil {
+			continue
+		}
+		if matchesPattern(namespace, []string{namespacePattern}) && matchesPattern(name, []string{namePattern}) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern returns true if name matches any of the glob patterns.
+// Malformed patterns are rejected by validation and never match.
+func matchesPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // exemptNamespaceWarning returns a non-empty warning message if the exempt namespace has a
 // non-privileged policy and sets pod security labels.
 func (a *Admission) exemptNamespaceWarning(exemptNamespace string, policy api.Policy, nsLabels map[string]string) string {
@@ -38,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2/ktesting"
 	admissionapi "k8s.io/pod-security-admission/admission/api"
 	"k8s.io/pod-security-admission/admission/api/load"
@@ -188,7 +189,7 @@ type testEvaluator struct {
 	delay time.Duration
 }
 
-func (t *testEvaluator) EvaluatePod(lv api.LevelVersion, meta *metav1.ObjectMeta, spec *corev1.PodSpec) []policy.CheckResult {
+func (t *testEvaluator) EvaluatePod(lv api.LevelVersion, meta *metav1.ObjectMeta, spec *corev1.PodSpec, _ ...policy.Option) []policy.CheckResult {
 	if t.delay > 0 {
 		time.Sleep(t.delay)
 	}
@@ -229,9 +230,10 @@ func (t *testPodLister) ListPods(ctx context.Context, namespace string) ([]*core
 
 func TestValidateNamespace(t *testing.T) {
 	testcases := []struct {
-		name                 string
-		exemptNamespaces     []string
-		exemptRuntimeClasses []string
+		name                    string
+		exemptNamespaces        []string
+		exemptNamespacePatterns []string
+		exemptRuntimeClasses    []string
 		// override default policy
 		defaultPolicy *api.Policy
 		// request subresource
@@ -282,6 +284,16 @@ func TestValidateNamespace(t *testing.T) {
 				`namespace "test" is exempt from Pod Security, and the policy (enforce=restricted:latest) will be ignored`,
 			},
 		},
+		{
+			name:                    "create restricted exempt by pattern",
+			newLabels:               map[string]string{api.EnforceLevelLabel: string(api.LevelRestricted)},
+			exemptNamespacePatterns: []string{"te*"},
+			expectAllowed:           true,
+			expectListPods:          false,
+			expectWarnings: []string{
+				`namespace "test" is exempt from Pod Security, and the policy (enforce=restricted:latest) will be ignored`,
+			},
+		},
 		{
 			name:           "create malformed level",
 			newLabels:      map[string]string{api.EnforceLevelLabel: "unknown"},
@@ -579,8 +591,9 @@ func TestValidateNamespace(t *testing.T) {
 				Evaluator: evaluator,
 				Configuration: &admissionapi.PodSecurityConfiguration{
 					Exemptions: admissionapi.PodSecurityExemptions{
-						Namespaces:     tc.exemptNamespaces,
-						RuntimeClasses: tc.exemptRuntimeClasses,
+						Namespaces:        tc.exemptNamespaces,
+						NamespacePatterns: tc.exemptNamespacePatterns,
+						RuntimeClasses:    tc.exemptRuntimeClasses,
 					},
 				},
 				Metrics:       &FakeRecorder{},
@@ -620,6 +633,8 @@ func TestValidateNamespace(t *testing.T) {
 func TestValidatePodAndController(t *testing.T) {
 	const (
 		exemptNs        = "exempt-ns"
+		exemptPatternNs = "preview-1234"
+		exemptLabeledNs = "exempt-labeled-ns"
 		implicitNs      = "implicit-ns"
 		privilegedNs    = "privileged-ns"
 		baselineNs      = "baseline-ns"
@@ -628,8 +643,10 @@ func TestValidatePodAndController(t *testing.T) {
 		restrictedNs    = "restricted-ns"
 		invalidNs       = "invalid-ns"
 
-		exemptUser         = "exempt-user"
-		exemptRuntimeClass = "exempt-runtimeclass"
+		exemptUser           = "exempt-user"
+		exemptGroup          = "exempt-group"
+		exemptServiceAccount = "system:serviceaccount:ci-runners:builder"
+		exemptRuntimeClass   = "exempt-runtimeclass"
 
 		podName = "test-pod"
 	)
@@ -687,6 +704,8 @@ func TestValidatePodAndController(t *testing.T) {
 	}
 	nsGetter := testNamespaceGetter{
 		exemptNs:        makeNs(api.LevelRestricted, api.LevelRestricted, api.LevelRestricted),
+		exemptPatternNs: makeNs(api.LevelRestricted, api.LevelRestricted, api.LevelRestricted),
+		exemptLabeledNs: makeNs(api.LevelRestricted, api.LevelRestricted, api.LevelRestricted),
 		implicitNs:      makeNs("", "", ""),
 		privilegedNs:    makeNs(api.LevelPrivileged, api.LevelPrivileged, api.LevelPrivileged),
 		baselineNs:      makeNs(api.LevelBaseline, api.LevelBaseline, api.LevelBaseline),
@@ -699,8 +718,13 @@ func TestValidatePodAndController(t *testing.T) {
 	config, err := load.LoadFromData(nil) // Start with the default config.
 	require.NoError(t, err, "loading default config")
 	config.Exemptions.Namespaces = []string{exemptNs}
+	config.Exemptions.NamespacePatterns = []string{"preview-*"}
+	config.Exemptions.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"exempt": "true"}}
+	nsGetter[exemptLabeledNs].Labels["exempt"] = "true"
 	config.Exemptions.RuntimeClasses = []string{exemptRuntimeClass}
 	config.Exemptions.Usernames = []string{exemptUser}
+	config.Exemptions.Groups = []string{exemptGroup}
+	config.Exemptions.ServiceAccounts = []string{"system:serviceaccount:ci-*:*"}
 
 	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
 	assert.NoError(t, err)
@@ -710,6 +734,7 @@ func TestValidatePodAndController(t *testing.T) {
 
 		namespace string
 		username  string
+		groups    []string
 
 		// pod and oldPod are used to populate obj and oldObj respectively, according to the test type (pod or deployment).
 		pod    *corev1.Pod
@@ -751,6 +776,20 @@ func TestValidatePodAndController(t *testing.T) {
 			expectAllowed: true,
 			expectExempt:  true,
 		},
+		{
+			desc:          "exempt namespace pattern",
+			namespace:     exemptPatternNs,
+			pod:           privilegedPod.DeepCopy(),
+			expectAllowed: true,
+			expectExempt:  true,
+		},
+		{
+			desc:          "exempt namespace selector",
+			namespace:     exemptLabeledNs,
+			pod:           privilegedPod.DeepCopy(),
+			expectAllowed: true,
+			expectExempt:  true,
+		},
 		{
 			desc:          "exempt user",
 			namespace:     restrictedNs,
@@ -759,6 +798,33 @@ func TestValidatePodAndController(t *testing.T) {
 			expectAllowed: true,
 			expectExempt:  true,
 		},
+		{
+			desc:          "exempt group",
+			namespace:     restrictedNs,
+			groups:        []string{"system:authenticated", exemptGroup},
+			pod:           privilegedPod.DeepCopy(),
+			expectAllowed: true,
+			expectExempt:  true,
+		},
+		{
+			desc:          "exempt service account pattern",
+			namespace:     restrictedNs,
+			username:      exemptServiceAccount,
+			pod:           privilegedPod.DeepCopy(),
+			expectAllowed: true,
+			expectExempt:  true,
+		},
+		{
+			desc:          "non-matching service account",
+			namespace:     restrictedNs,
+			username:      "system:serviceaccount:default:builder",
+			pod:           privilegedPod.DeepCopy(),
+			expectAllowed: false,
+			expectReason:  metav1.StatusReasonForbidden,
+			expectEnforce: api.LevelRestricted,
+			expectWarning: api.LevelRestricted,
+			expectAudit:   api.LevelRestricted,
+		},
 		{
 			desc:          "exempt runtimeClass",
 			namespace:     restrictedNs,
@@ -987,6 +1053,7 @@ func TestValidatePodAndController(t *testing.T) {
 			if tc.operation != "" {
 				attrs.Operation = tc.operation
 			}
+			attrs.Groups = tc.groups
 			if tc.username != "" {
 				attrs.Username = tc.username
 			}
@@ -1060,6 +1127,301 @@ func TestValidatePodAndController(t *testing.T) {
 	}
 }
 
+func TestEnforceDenyCauses(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+
+	pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	pod.Name = "test-pod"
+	pod.Spec.HostNetwork = true
+	pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+	a := &Admission{
+		PodLister:     &testPodLister{},
+		Evaluator:     evaluator,
+		Configuration: config,
+		Metrics:       &FakeRecorder{},
+		NamespaceGetter: testNamespaceGetter{
+			"baseline-ns": {ObjectMeta: metav1.ObjectMeta{
+				Name:   "baseline-ns",
+				Labels: map[string]string{api.EnforceLevelLabel: string(api.LevelBaseline)},
+			}},
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, a.ValidateConfiguration(), "ValidateConfiguration()")
+
+	attrs := &api.AttributesRecord{
+		Name:      pod.Name,
+		Namespace: "baseline-ns",
+		Kind:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Resource:  schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+		Operation: admissionv1.Create,
+		Object:    pod,
+	}
+	response := a.Validate(ctx, attrs)
+	require.False(t, response.Allowed)
+	require.NotNil(t, response.Result)
+	assert.Equal(t, metav1.StatusReasonForbidden, response.Result.Reason)
+	require.NotNil(t, response.Result.Details)
+	assert.Equal(t, pod.Name, response.Result.Details.Name)
+	assert.Equal(t, []metav1.StatusCause{
+		{
+			Type:    metav1.CauseType(field.ErrorTypeForbidden),
+			Message: "host namespaces: Forbidden: true",
+			Field:   "spec.hostNetwork",
+		},
+		{
+			Type:    metav1.CauseType(field.ErrorTypeForbidden),
+			Message: "privileged: Forbidden: true",
+			Field:   "spec.containers[0].securityContext.privileged",
+		},
+	}, response.Result.Details.Causes)
+}
+
+func TestCheckExemptions(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	config.Exemptions.Checks = []admissionapi.PodSecurityCheckExemption{{
+		CheckIDs:          []string{"hostNamespaces", "hostPorts"},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "infra"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "node-agent"}},
+	}}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+
+	enforceBaseline := map[string]string{api.EnforceLevelLabel: string(api.LevelBaseline)}
+	a := &Admission{
+		PodLister:     &testPodLister{},
+		Evaluator:     evaluator,
+		Configuration: config,
+		Metrics:       &FakeRecorder{},
+		NamespaceGetter: testNamespaceGetter{
+			"infra": {ObjectMeta: metav1.ObjectMeta{
+				Name:   "infra",
+				Labels: map[string]string{api.EnforceLevelLabel: string(api.LevelBaseline), "team": "infra"},
+			}},
+			"apps": {ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: enforceBaseline}},
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, a.ValidateConfiguration(), "ValidateConfiguration()")
+
+	testCases := []struct {
+		name              string
+		namespace         string
+		podLabels         map[string]string
+		privileged        bool
+		expectAllowed     bool
+		expectExemptions  string
+		expectDenyMessage string
+	}{
+		{
+			name:             "matching namespace and pod",
+			namespace:        "infra",
+			podLabels:        map[string]string{"app": "node-agent"},
+			expectAllowed:    true,
+			expectExemptions: "hostNamespaces,hostPorts",
+		},
+		{
+			name:              "non-matching pod",
+			namespace:         "infra",
+			podLabels:         map[string]string{"app": "web"},
+			expectDenyMessage: `pods "test-pod" is forbidden: violates PodSecurity "baseline:latest": host namespaces (hostNetwork=true)`,
+		},
+		{
+			name:              "non-matching namespace",
+			namespace:         "apps",
+			podLabels:         map[string]string{"app": "node-agent"},
+			expectDenyMessage: `pods "test-pod" is forbidden: violates PodSecurity "baseline:latest": host namespaces (hostNetwork=true)`,
+		},
+		{
+			name:              "other checks still enforced",
+			namespace:         "infra",
+			podLabels:         map[string]string{"app": "node-agent"},
+			privileged:        true,
+			expectExemptions:  "hostNamespaces,hostPorts",
+			expectDenyMessage: `pods "test-pod" is forbidden: violates PodSecurity "baseline:latest": privileged (container "container1" must not set securityContext.privileged=true)`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+			require.NoError(t, err)
+			pod.Name = "test-pod"
+			pod.Labels = tc.podLabels
+			pod.Spec.HostNetwork = true
+			if tc.privileged {
+				pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+			}
+
+			attrs := &api.AttributesRecord{
+				Name:      pod.Name,
+				Namespace: tc.namespace,
+				Kind:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				Resource:  schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				Operation: admissionv1.Create,
+				Object:    pod,
+			}
+			response := a.Validate(ctx, attrs)
+			assert.Equal(t, tc.expectAllowed, response.Allowed)
+			if !tc.expectAllowed {
+				require.NotNil(t, response.Result)
+				assert.Equal(t, tc.expectDenyMessage, response.Result.Message)
+			}
+			assert.Equal(t, tc.expectExemptions, response.AuditAnnotations[api.ExemptChecksAnnotationKey])
+
+			// The exported EvaluatePod looks up the namespace labels matched by the exemptions.
+			nsPolicy, _ := a.PolicyToEvaluate(a.NamespaceGetter.(testNamespaceGetter)[tc.namespace].Labels)
+			response = a.EvaluatePod(ctx, nsPolicy, nil, &pod.ObjectMeta, &pod.Spec, attrs, true)
+			assert.Equal(t, tc.expectAllowed, response.Allowed)
+		})
+	}
+
+	// Exempt checks are not reported when the namespace level is raised.
+	exemptPod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	exemptPod.Name = "node-agent"
+	exemptPod.Labels = map[string]string{"app": "node-agent"}
+	exemptPod.Spec.HostNetwork = true
+	a.PodLister = &testPodLister{pods: []*corev1.Pod{exemptPod}}
+	baseline := api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}
+	assert.Empty(t, a.EvaluatePodsInNamespace(ctx, "infra", baseline))
+	assert.Empty(t, a.evaluatePodsInNamespace(ctx, "apps", map[string]string{"team": "infra"}, baseline))
+	assert.Equal(t, []string{
+		`existing pods in namespace "apps" violate the new PodSecurity enforce level "baseline:latest"`,
+		`node-agent: host namespaces`,
+	}, a.EvaluatePodsInNamespace(ctx, "apps", baseline))
+}
+
+func TestCustomLevels(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	config.Levels = []admissionapi.PodSecurityLevel{
+		{Name: "baseline-minus-hostNamespaces", Base: "baseline", RemoveChecks: []string{"hostNamespaces"}, After: "privileged"},
+		{Name: "baseline-plus-runAsNonRoot", Base: "baseline", AddChecks: []string{"runAsNonRoot"}},
+	}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil, EvaluatorOptions(config)...)
+	require.NoError(t, err)
+
+	a := &Admission{
+		PodLister:     &testPodLister{},
+		Evaluator:     evaluator,
+		Configuration: config,
+		Metrics:       &FakeRecorder{},
+		NamespaceGetter: testNamespaceGetter{
+			"relaxed": {ObjectMeta: metav1.ObjectMeta{
+				Name:   "relaxed",
+				Labels: map[string]string{api.EnforceLevelLabel: "baseline-minus-hostNamespaces"},
+			}},
+			"strict": {ObjectMeta: metav1.ObjectMeta{
+				Name:   "strict",
+				Labels: map[string]string{api.EnforceLevelLabel: "baseline-plus-runAsNonRoot"},
+			}},
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, a.ValidateConfiguration(), "ValidateConfiguration()")
+	assert.Equal(t, 1, a.Levels().CompareLevels("baseline-plus-runAsNonRoot", api.LevelBaseline))
+	assert.Equal(t, -1, a.Levels().CompareLevels("baseline-minus-hostNamespaces", api.LevelBaseline))
+
+	// An Admission without custom levels does not affect the levels of another.
+	defaultConfig, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	other := &Admission{
+		PodLister:       &testPodLister{},
+		Evaluator:       evaluator,
+		Configuration:   defaultConfig,
+		Metrics:         &FakeRecorder{},
+		NamespaceGetter: a.NamespaceGetter,
+	}
+	require.NoError(t, other.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, other.ValidateConfiguration(), "ValidateConfiguration()")
+	_, errs := other.PolicyToEvaluate(map[string]string{api.EnforceLevelLabel: "baseline-plus-runAsNonRoot"})
+	assert.NotEmpty(t, errs)
+	_, errs = a.PolicyToEvaluate(map[string]string{api.EnforceLevelLabel: "baseline-plus-runAsNonRoot"})
+	assert.Empty(t, errs)
+
+	testCases := []struct {
+		name              string
+		namespace         string
+		privileged        bool
+		expectDenyMessage string
+	}{
+		{
+			name:      "removed check",
+			namespace: "relaxed",
+		},
+		{
+			name:              "base checks still enforced",
+			namespace:         "relaxed",
+			privileged:        true,
+			expectDenyMessage: `pods "test-pod" is forbidden: violates PodSecurity "baseline-minus-hostNamespaces:latest": privileged (container "container1" must not set securityContext.privileged=true)`,
+		},
+		{
+			name:              "added check",
+			namespace:         "strict",
+			expectDenyMessage: `pods "test-pod" is forbidden: violates PodSecurity "baseline-plus-runAsNonRoot:latest": host namespaces (hostNetwork=true), runAsNonRoot != true (pod or containers "initcontainer1", "container1" must set securityContext.runAsNonRoot=true)`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+			require.NoError(t, err)
+			pod.Name = "test-pod"
+			pod.Spec.HostNetwork = true
+			if tc.privileged {
+				pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+			}
+
+			attrs := &api.AttributesRecord{
+				Name:      pod.Name,
+				Namespace: tc.namespace,
+				Kind:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				Resource:  schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				Operation: admissionv1.Create,
+				Object:    pod,
+			}
+			response := a.Validate(ctx, attrs)
+			assert.Equal(t, tc.expectDenyMessage == "", response.Allowed)
+			if tc.expectDenyMessage != "" {
+				require.NotNil(t, response.Result)
+				assert.Equal(t, tc.expectDenyMessage, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestEvaluatorOptionsChecks(t *testing.T) {
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	config.Checks = admissionapi.PodSecurityChecks{
+		Images:   &admissionapi.PodSecurityImageCheck{Level: "baseline", DisallowedTags: []string{"latest"}},
+		Hardened: []string{"readOnlyRootFilesystem"},
+	}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil, EvaluatorOptions(config)...)
+	require.NoError(t, err)
+
+	pod, err := test.GetMinimalValidPod(api.LevelRestricted, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	pod.Spec.InitContainers[0].Image = "nginx"
+	pod.Spec.Containers[0].Image = "nginx:1.25"
+
+	baseline := policy.AggregateCheckResults(evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec))
+	assert.Equal(t, []string{"disallowed images"}, baseline.ForbiddenReasons)
+	restricted := policy.AggregateCheckResults(evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec))
+	assert.ElementsMatch(t, []string{"disallowed images", "writable root filesystem"}, restricted.ForbiddenReasons)
+}
+
 type FakeRecorder struct {
 	evaluations []MetricsRecord
 	exemptions  []MetricsRecord
@@ -18,8 +18,11 @@ package api
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	policyapi "k8s.io/pod-security-admission/api"
 )
 
@@ -27,6 +30,11 @@ var requiredErr = fmt.Errorf("required")
 
 // TODO: deduplicate against PolicyToEvaluate
 func ToPolicy(defaults PodSecurityDefaults) (policyapi.Policy, error) {
+	return ToPolicyWithLevels(defaults, nil)
+}
+
+// ToPolicyWithLevels is like ToPolicy, but also accepts the custom levels of the given set as default levels.
+func ToPolicyWithLevels(defaults PodSecurityDefaults, levels *policyapi.LevelSet) (policyapi.Policy, error) {
 	var (
 		err  error
 		errs []error
@@ -36,7 +44,7 @@ func ToPolicy(defaults PodSecurityDefaults) (policyapi.Policy, error) {
 	if len(defaults.Enforce) == 0 {
 		errs = appendErr(errs, requiredErr, "enforce")
 	} else {
-		p.Enforce.Level, err = policyapi.ParseLevel(defaults.Enforce)
+		p.Enforce.Level, err = levels.ParseLevel(defaults.Enforce)
 		errs = appendErr(errs, err, "enforce")
 	}
 
@@ -50,7 +58,7 @@ func ToPolicy(defaults PodSecurityDefaults) (policyapi.Policy, error) {
 	if len(defaults.Audit) == 0 {
 		errs = appendErr(errs, requiredErr, "audit")
 	} else {
-		p.Audit.Level, err = policyapi.ParseLevel(defaults.Audit)
+		p.Audit.Level, err = levels.ParseLevel(defaults.Audit)
 		errs = appendErr(errs, err, "audit")
 	}
 
@@ -64,7 +72,7 @@ func ToPolicy(defaults PodSecurityDefaults) (policyapi.Policy, error) {
 	if len(defaults.Warn) == 0 {
 		errs = appendErr(errs, requiredErr, "warn")
 	} else {
-		p.Warn.Level, err = policyapi.ParseLevel(defaults.Warn)
+		p.Warn.Level, err = levels.ParseLevel(defaults.Warn)
 		errs = appendErr(errs, err, "warn")
 	}
 
@@ -78,6 +86,40 @@ func ToPolicy(defaults PodSecurityDefaults) (policyapi.Policy, error) {
 	return p, errors.NewAggregate(errs)
 }
 
+// ToCustomLevels converts the declared custom levels to their ordering, defaulting After to the base level.
+func ToCustomLevels(levels []PodSecurityLevel) []policyapi.CustomLevel {
+	var customLevels []policyapi.CustomLevel
+	for _, l := range levels {
+		after := l.After
+		if after == "" {
+			after = l.Base
+		}
+		customLevels = append(customLevels, policyapi.CustomLevel{
+			Name:  policyapi.Level(l.Name),
+			After: policyapi.Level(after),
+		})
+	}
+	return customLevels
+}
+
+// SplitServiceAccountPattern splits a service account exemption of the form
+// "system:serviceaccount:<namespace>:<name>" into its namespace and name glob patterns.
+func SplitServiceAccountPattern(pattern string) (namespace, name string, err error) {
+	if !strings.HasPrefix(pattern, serviceaccount.ServiceAccountUsernamePrefix) {
+		return "", "", fmt.Errorf("must start with %q", serviceaccount.ServiceAccountUsernamePrefix)
+	}
+	parts := strings.Split(strings.TrimPrefix(pattern, serviceaccount.ServiceAccountUsernamePrefix), serviceaccount.ServiceAccountUsernameSeparator)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("must be of the form %q", serviceaccount.ServiceAccountUsernamePrefix+"<namespace>:<name>")
+	}
+	for _, part := range parts {
+		if _, err := path.Match(part, ""); err != nil {
+			return "", "", err
+		}
+	}
+	return parts[0], parts[1], nil
+}
+
 // appendErr is a helper function to collect field-specific errors.
 func appendErr(errs []error, err error, field string) []error {
 	if err != nil {
@@ -28,17 +28,28 @@ import (
 )
 
 func LoadFromFile(file string) (*api.PodSecurityConfiguration, error) {
-	if len(file) == 0 {
-		// no file specified, use default config
-		return LoadFromData(nil)
-	}
+	_, config, err := LoadFromFileWithData(file)
+	return config, err
+}
 
-	// read from file
-	data, err := os.ReadFile(file)
+// LoadFromFileWithData loads the configuration like LoadFromFile, and also returns the content of the file,
+// so that callers reloading the file can tell whether it changed.
+func LoadFromFileWithData(file string) ([]byte, *api.PodSecurityConfiguration, error) {
+	var data []byte
+	if len(file) > 0 {
+		// read from file
+		var err error
+		data, err = os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	// no file specified, use default config
+	config, err := LoadFromData(data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return LoadFromData(data)
+	return data, config, nil
 }
 
 func LoadFromReader(reader io.Reader) (*api.PodSecurityConfiguration, error) {
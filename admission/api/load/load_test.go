@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utiltesting "k8s.io/client-go/util/testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -173,6 +174,40 @@ func TestLoadFromFile(t *testing.T) {
 	}
 }
 
+func TestLoadFromFileWithData(t *testing.T) {
+	// no file
+	{
+		data, config, err := LoadFromFileWithData("")
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if data != nil {
+			t.Fatalf("unexpected data: %q", data)
+		}
+		if !reflect.DeepEqual(config, defaultConfig) {
+			t.Fatalf("unexpected config:\n%s", cmp.Diff(defaultConfig, config))
+		}
+	}
+
+	// valid file
+	{
+		input := `{
+			"apiVersion":"pod-security.admission.config.k8s.io/v1",
+			"kind":"PodSecurityConfiguration",
+			"defaults":{"enforce":"baseline"}}`
+		data, config, err := LoadFromFileWithData(writeTempFile(t, input))
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if string(data) != input {
+			t.Fatalf("unexpected data: %q", data)
+		}
+		if config.Defaults.Enforce != "baseline" {
+			t.Fatalf("unexpected enforce level: %q", config.Defaults.Enforce)
+		}
+	}
+}
+
 func TestLoadFromReader(t *testing.T) {
 	// no reader
 	{
@@ -419,6 +454,189 @@ exemptions:
 				},
 			},
 		},
+		{
+			name: "v1 - check exemptions",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+defaults:
+  enforce: baseline
+exemptions:
+  checks:
+  - checkIDs: ["hostNamespaces","hostPorts"]
+    namespaceSelector:
+      matchLabels:
+        team: infra
+    podSelector:
+      matchLabels:
+        app: node-agent
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "baseline", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				Exemptions: api.PodSecurityExemptions{
+					Checks: []api.PodSecurityCheckExemption{{
+						CheckIDs:          []string{"hostNamespaces", "hostPorts"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "infra"}},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "node-agent"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "v1 - custom levels",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+defaults:
+  enforce: baseline-minus-hostPorts
+levels:
+- name: restricted-plus
+  base: restricted
+  addChecks: ["trustedRegistry"]
+- name: baseline-minus-hostPorts
+  base: baseline
+  removeChecks: ["hostPorts"]
+  after: privileged
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "baseline-minus-hostPorts", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				Levels: []api.PodSecurityLevel{
+					{Name: "restricted-plus", Base: "restricted", AddChecks: []string{"trustedRegistry"}},
+					{Name: "baseline-minus-hostPorts", Base: "baseline", RemoveChecks: []string{"hostPorts"}, After: "privileged"},
+				},
+			},
+		},
+		{
+			name: "v1 - CEL checks",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+checks:
+  cel:
+  - id: trustedRegistry
+    level: restricted
+    minimumVersion: v1.0
+    expression: "podSpec.containers.all(c, c.image.startsWith('registry.example.com/'))"
+    reason: untrusted registry
+    fieldPath: spec.containers[*].image
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "privileged", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					CEL: []api.PodSecurityCELCheck{{
+						ID:             "trustedRegistry",
+						Level:          "restricted",
+						MinimumVersion: "v1.0",
+						Expression:     "podSpec.containers.all(c, c.image.startsWith('registry.example.com/'))",
+						Reason:         "untrusted registry",
+						FieldPath:      "spec.containers[*].image",
+					}},
+				},
+			},
+		},
+		{
+			name: "v1 - image check",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+checks:
+  images:
+    level: baseline
+    allowedRegistries: ["registry.example.com/"]
+    disallowedTags: ["latest"]
+    requireDigest: true
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "privileged", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Images: &api.PodSecurityImageCheck{
+						Level:             "baseline",
+						AllowedRegistries: []string{"registry.example.com/"},
+						DisallowedTags:    []string{"latest"},
+						RequireDigest:     true,
+					},
+				},
+			},
+		},
+		{
+			name: "v1 - resource check",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+checks:
+  resources:
+    level: restricted
+    minimum:
+      cpu: 10m
+    maximum:
+      cpu: "4"
+      memory: 8Gi
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "privileged", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Resources: &api.PodSecurityResourceCheck{
+						Level:   "restricted",
+						Minimum: map[string]string{"cpu": "10m"},
+						Maximum: map[string]string{"cpu": "4", "memory": "8Gi"},
+					},
+				},
+			},
+		},
+		{
+			name: "v1 - allow-lists",
+			data: []byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+allowLists:
+- level: baseline
+  sysctls:
+    add: ["net.core.somaxconn"]
+  capabilities:
+    remove: ["CHOWN"]
+- level: restricted
+  volumeTypes:
+    add: ["nfs"]
+`),
+			expectConfig: &api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce: "privileged", EnforceVersion: "latest",
+					Warn: "privileged", WarnVersion: "latest",
+					Audit: "privileged", AuditVersion: "latest",
+				},
+				AllowLists: []api.PodSecurityAllowLists{
+					{
+						Level:        "baseline",
+						Sysctls:      api.PodSecurityAllowListOverride{Add: []string{"net.core.somaxconn"}},
+						Capabilities: api.PodSecurityAllowListOverride{Remove: []string{"CHOWN"}},
+					},
+					{
+						Level:       "restricted",
+						VolumeTypes: api.PodSecurityAllowListOverride{Add: []string{"nfs"}},
+					},
+				},
+			},
+		},
 		{
 			name:      "missing apiVersion",
 			data:      []byte(`{"kind":"PodSecurityConfiguration"}`),
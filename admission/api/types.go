@@ -26,6 +26,9 @@ type PodSecurityConfiguration struct {
 	metav1.TypeMeta
 	Defaults   PodSecurityDefaults
 	Exemptions PodSecurityExemptions
+	Levels     []PodSecurityLevel
+	Checks     PodSecurityChecks
+	AllowLists []PodSecurityAllowLists
 }
 
 type PodSecurityDefaults struct {
@@ -38,7 +41,79 @@ type PodSecurityDefaults struct {
 }
 
 type PodSecurityExemptions struct {
-	Usernames      []string
-	Namespaces     []string
-	RuntimeClasses []string
+	Usernames         []string
+	Groups            []string
+	ServiceAccounts   []string
+	Namespaces        []string
+	NamespacePatterns []string
+	NamespaceSelector *metav1.LabelSelector
+	RuntimeClasses    []string
+	Checks            []PodSecurityCheckExemption
+}
+
+// PodSecurityCheckExemption waives individual checks for matching pods.
+// The exemption applies when both the namespace and pod labels match;
+// an unset selector matches everything.
+type PodSecurityCheckExemption struct {
+	CheckIDs          []string
+	NamespaceSelector *metav1.LabelSelector
+	PodSelector       *metav1.LabelSelector
+}
+
+// PodSecurityLevel declares a custom level as the checks of a built-in level,
+// with checks added or removed.
+type PodSecurityLevel struct {
+	Name         string
+	Base         string
+	AddChecks    []string
+	RemoveChecks []string
+	After        string
+}
+
+// PodSecurityChecks configures checks in addition to the built-in checks.
+type PodSecurityChecks struct {
+	CEL       []PodSecurityCELCheck
+	Images    *PodSecurityImageCheck
+	Resources *PodSecurityResourceCheck
+	Hardened  []string
+}
+
+// PodSecurityCELCheck declares a check whose logic is a CEL expression.
+type PodSecurityCELCheck struct {
+	ID                string
+	Level             string
+	MinimumVersion    string
+	Expression        string
+	Reason            string
+	MessageExpression string
+	FieldPath         string
+}
+
+// PodSecurityImageCheck configures the check of container images.
+type PodSecurityImageCheck struct {
+	Level             string
+	AllowedRegistries []string
+	DisallowedTags    []string
+	RequireDigest     bool
+}
+
+// PodSecurityResourceCheck configures the check of container resource requests and limits.
+type PodSecurityResourceCheck struct {
+	Level   string
+	Minimum map[string]string
+	Maximum map[string]string
+}
+
+// PodSecurityAllowLists overrides the allow-lists of built-in checks at a level.
+type PodSecurityAllowLists struct {
+	Level        string
+	Sysctls      PodSecurityAllowListOverride
+	Capabilities PodSecurityAllowListOverride
+	VolumeTypes  PodSecurityAllowListOverride
+}
+
+// PodSecurityAllowListOverride extends or narrows an allow-list.
+type PodSecurityAllowListOverride struct {
+	Add    []string
+	Remove []string
 }
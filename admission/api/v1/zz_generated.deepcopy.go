@@ -22,15 +22,159 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAllowListOverride) DeepCopyInto(out *PodSecurityAllowListOverride) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAllowListOverride.
+func (in *PodSecurityAllowListOverride) DeepCopy() *PodSecurityAllowListOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAllowListOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAllowLists) DeepCopyInto(out *PodSecurityAllowLists) {
+	*out = *in
+	in.Sysctls.DeepCopyInto(&out.Sysctls)
+	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	in.VolumeTypes.DeepCopyInto(&out.VolumeTypes)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAllowLists.
+func (in *PodSecurityAllowLists) DeepCopy() *PodSecurityAllowLists {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAllowLists)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityCELCheck) DeepCopyInto(out *PodSecurityCELCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityCELCheck.
+func (in *PodSecurityCELCheck) DeepCopy() *PodSecurityCELCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityCELCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityCheckExemption) DeepCopyInto(out *PodSecurityCheckExemption) {
+	*out = *in
+	if in.CheckIDs != nil {
+		in, out := &in.CheckIDs, &out.CheckIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityCheckExemption.
+func (in *PodSecurityCheckExemption) DeepCopy() *PodSecurityCheckExemption {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityCheckExemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityChecks) DeepCopyInto(out *PodSecurityChecks) {
+	*out = *in
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = make([]PodSecurityCELCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(PodSecurityImageCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(PodSecurityResourceCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hardened != nil {
+		in, out := &in.Hardened, &out.Hardened
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityChecks.
+func (in *PodSecurityChecks) DeepCopy() *PodSecurityChecks {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityChecks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurityConfiguration) DeepCopyInto(out *PodSecurityConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.Defaults = in.Defaults
 	in.Exemptions.DeepCopyInto(&out.Exemptions)
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]PodSecurityLevel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Checks.DeepCopyInto(&out.Checks)
+	if in.AllowLists != nil {
+		in, out := &in.AllowLists, &out.AllowLists
+		*out = make([]PodSecurityAllowLists, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -76,16 +220,43 @@ func (in *PodSecurityExemptions) DeepCopyInto(out *PodSecurityExemptions) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespacePatterns != nil {
+		in, out := &in.NamespacePatterns, &out.NamespacePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RuntimeClasses != nil {
 		in, out := &in.RuntimeClasses, &out.RuntimeClasses
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]PodSecurityCheckExemption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -98,3 +269,85 @@ func (in *PodSecurityExemptions) DeepCopy() *PodSecurityExemptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityImageCheck) DeepCopyInto(out *PodSecurityImageCheck) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisallowedTags != nil {
+		in, out := &in.DisallowedTags, &out.DisallowedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityImageCheck.
+func (in *PodSecurityImageCheck) DeepCopy() *PodSecurityImageCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityImageCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityLevel) DeepCopyInto(out *PodSecurityLevel) {
+	*out = *in
+	if in.AddChecks != nil {
+		in, out := &in.AddChecks, &out.AddChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveChecks != nil {
+		in, out := &in.RemoveChecks, &out.RemoveChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityLevel.
+func (in *PodSecurityLevel) DeepCopy() *PodSecurityLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityResourceCheck) DeepCopyInto(out *PodSecurityResourceCheck) {
+	*out = *in
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityResourceCheck.
+func (in *PodSecurityResourceCheck) DeepCopy() *PodSecurityResourceCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityResourceCheck)
+	in.DeepCopyInto(out)
+	return out
+}
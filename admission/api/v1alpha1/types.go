@@ -26,6 +26,13 @@ type PodSecurityConfiguration struct {
 	metav1.TypeMeta
 	Defaults   PodSecurityDefaults   `json:"defaults"`
 	Exemptions PodSecurityExemptions `json:"exemptions"`
+	// levels declares custom levels, which may be used as the value of the level
+	// namespace labels and defaults in addition to the built-in levels.
+	Levels []PodSecurityLevel `json:"levels,omitempty"`
+	// checks configures checks in addition to the built-in checks.
+	Checks PodSecurityChecks `json:"checks,omitempty"`
+	// allowLists extends or narrows the allow-lists of built-in checks at a level.
+	AllowLists []PodSecurityAllowLists `json:"allowLists,omitempty"`
 }
 
 type PodSecurityDefaults struct {
@@ -38,7 +45,137 @@ type PodSecurityDefaults struct {
 }
 
 type PodSecurityExemptions struct {
-	Usernames      []string `json:"usernames,omitempty"`
-	Namespaces     []string `json:"namespaces,omitempty"`
-	RuntimeClasses []string `json:"runtimeClasses,omitempty"`
+	Usernames []string `json:"usernames,omitempty"`
+	// groups exempts requests from users in any of the listed groups.
+	Groups []string `json:"groups,omitempty"`
+	// serviceAccounts exempts requests from matching service accounts. Entries have the form
+	// "system:serviceaccount:<namespace>:<name>", where the namespace and name may be glob patterns,
+	// e.g. "system:serviceaccount:ci-*:*". The pattern syntax is that of path.Match.
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+	Namespaces      []string `json:"namespaces,omitempty"`
+	// namespacePatterns exempts namespaces whose name matches one of the glob patterns,
+	// e.g. "preview-*". The pattern syntax is that of path.Match.
+	NamespacePatterns []string `json:"namespacePatterns,omitempty"`
+	// namespaceSelector exempts namespaces whose labels match the selector.
+	// Note that anyone able to label a namespace can then opt it out of enforcement.
+	NamespaceSelector *metav1.LabelSelector       `json:"namespaceSelector,omitempty"`
+	RuntimeClasses    []string                    `json:"runtimeClasses,omitempty"`
+	Checks            []PodSecurityCheckExemption `json:"checks,omitempty"`
+}
+
+// PodSecurityCheckExemption waives individual checks for pods matching the selectors,
+// without exempting the pods from the rest of the policy.
+type PodSecurityCheckExemption struct {
+	// checkIDs lists the IDs of the checks to waive, e.g. "hostPorts" or "sysctls".
+	CheckIDs []string `json:"checkIDs"`
+	// namespaceSelector restricts the exemption to namespaces with matching labels.
+	// If unset, pods in any namespace match.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// podSelector restricts the exemption to pods with matching labels.
+	// For workload resources, the labels of the pod template are matched.
+	// If unset, any pod matches.
+	// At least one of namespaceSelector and podSelector must be set.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// PodSecurityLevel declares a custom level as the checks of a built-in level,
+// with checks added or removed, e.g. "restricted-plus" or "baseline-minus-hostPorts".
+type PodSecurityLevel struct {
+	// name of the level. Must be a valid label value, and must not be a built-in level.
+	Name string `json:"name"`
+	// base is the built-in level whose checks the level evaluates: privileged, baseline, or restricted.
+	Base string `json:"base"`
+	// addChecks lists the IDs of checks to evaluate in addition to the checks of the base level,
+	// e.g. "runAsNonRoot" for a baseline base. Added restricted checks replace the baseline checks they override.
+	AddChecks []string `json:"addChecks,omitempty"`
+	// removeChecks lists the IDs of checks of the base level to skip, e.g. "hostPorts".
+	RemoveChecks []string `json:"removeChecks,omitempty"`
+	// after is the level immediately less strict than this level, which orders the levels, e.g. when
+	// defaulting the warn level to a stricter enforce level. It must be a built-in level or a level
+	// declared earlier in the list, and no two levels may share it. Defaults to the base level, so
+	// levels that remove checks should set it to a less strict level.
+	After string `json:"after,omitempty"`
+}
+
+// PodSecurityChecks configures checks in addition to the built-in checks.
+type PodSecurityChecks struct {
+	// cel declares checks whose logic is a CEL expression over the pod metadata and spec.
+	CEL []PodSecurityCELCheck `json:"cel,omitempty"`
+	// images optionally configures the check of container images, which has the ID "images".
+	Images *PodSecurityImageCheck `json:"images,omitempty"`
+	// resources optionally configures the check of container resource requests and limits,
+	// which has the ID "resources".
+	Resources *PodSecurityResourceCheck `json:"resources,omitempty"`
+	// hardened lists the IDs of opt-in restricted level checks to enable,
+	// e.g. "readOnlyRootFilesystem" or "automountServiceAccountToken".
+	Hardened []string `json:"hardened,omitempty"`
+}
+
+// PodSecurityCELCheck declares a check whose logic is a CEL expression. The expressions may refer to
+// the podMetadata and podSpec variables, which hold the pod metadata and spec as serialized to JSON.
+type PodSecurityCELCheck struct {
+	// id is the unique ID of the check, which may be used in check exemptions and custom levels.
+	ID string `json:"id"`
+	// level is the level the check belongs to: baseline or restricted.
+	Level string `json:"level"`
+	// minimumVersion is the first policy version the check applies to, e.g. "v1.0".
+	MinimumVersion string `json:"minimumVersion"`
+	// expression evaluates to true if the pod is allowed,
+	// e.g. "podSpec.containers.all(c, c.image.startsWith('registry.example.com/'))".
+	Expression string `json:"expression"`
+	// reason is the short reason reported for pods that are not allowed, e.g. "untrusted registry".
+	Reason string `json:"reason"`
+	// messageExpression optionally evaluates to a string detailing why the pod is not allowed.
+	MessageExpression string `json:"messageExpression,omitempty"`
+	// fieldPath is the path of the field reported in field errors, e.g. "spec.containers[*].image".
+	// Defaults to "spec".
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// PodSecurityImageCheck configures the check of the images of init, regular and ephemeral containers.
+type PodSecurityImageCheck struct {
+	// level is the level the check belongs to: baseline or restricted.
+	Level string `json:"level"`
+	// allowedRegistries lists the prefixes images must start with, e.g. "registry.example.com/".
+	// Images are matched after adding the implicit Docker Hub registry, so "nginx" matches "docker.io/library/".
+	// If empty, images may be pulled from any registry.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// disallowedTags lists the tags images must not use, e.g. "latest".
+	// Images without a tag or digest are treated as using the "latest" tag.
+	DisallowedTags []string `json:"disallowedTags,omitempty"`
+	// requireDigest requires images to be referenced by digest.
+	RequireDigest bool `json:"requireDigest,omitempty"`
+}
+
+// PodSecurityResourceCheck configures the check requiring init and regular containers
+// to declare CPU and memory requests and limits.
+type PodSecurityResourceCheck struct {
+	// level is the level the check belongs to: baseline or restricted.
+	Level string `json:"level"`
+	// minimum optionally maps cpu and memory to the smallest quantity containers may request or limit, e.g. "10m".
+	Minimum map[string]string `json:"minimum,omitempty"`
+	// maximum optionally maps cpu and memory to the largest quantity containers may request or limit, e.g. "4Gi".
+	Maximum map[string]string `json:"maximum,omitempty"`
+}
+
+// PodSecurityAllowLists overrides the allow-lists of built-in checks at a level.
+// The allow-lists of a custom level default to the allow-lists of its base level.
+type PodSecurityAllowLists struct {
+	// level is the level the overrides apply to: baseline, restricted or a custom level.
+	Level string `json:"level"`
+	// sysctls overrides the sysctls allowed by the sysctls check, e.g. "net.core.somaxconn".
+	Sysctls PodSecurityAllowListOverride `json:"sysctls,omitempty"`
+	// capabilities overrides the capabilities containers may add according to the capabilities_baseline check,
+	// e.g. "NET_RAW". At the restricted level, capabilities_baseline is replaced by capabilities_restricted.
+	Capabilities PodSecurityAllowListOverride `json:"capabilities,omitempty"`
+	// volumeTypes overrides the volume types allowed by the restrictedVolumes check, e.g. "nfs".
+	VolumeTypes PodSecurityAllowListOverride `json:"volumeTypes,omitempty"`
+}
+
+// PodSecurityAllowListOverride extends or narrows an allow-list.
+type PodSecurityAllowListOverride struct {
+	// add lists values to allow in addition to the built-in allow-list.
+	Add []string `json:"add,omitempty"`
+	// remove lists values of the built-in allow-list to forbid.
+	Remove []string `json:"remove,omitempty"`
 }
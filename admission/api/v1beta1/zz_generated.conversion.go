@@ -24,6 +24,7 @@ package v1beta1
 import (
 	unsafe "unsafe"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "k8s.io/pod-security-admission/admission/api"
@@ -36,6 +37,56 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*PodSecurityAllowListOverride)(nil), (*api.PodSecurityAllowListOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(a.(*PodSecurityAllowListOverride), b.(*api.PodSecurityAllowListOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityAllowListOverride)(nil), (*PodSecurityAllowListOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(a.(*api.PodSecurityAllowListOverride), b.(*PodSecurityAllowListOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityAllowLists)(nil), (*api.PodSecurityAllowLists)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityAllowLists_To_api_PodSecurityAllowLists(a.(*PodSecurityAllowLists), b.(*api.PodSecurityAllowLists), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityAllowLists)(nil), (*PodSecurityAllowLists)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityAllowLists_To_v1beta1_PodSecurityAllowLists(a.(*api.PodSecurityAllowLists), b.(*PodSecurityAllowLists), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityCELCheck)(nil), (*api.PodSecurityCELCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityCELCheck_To_api_PodSecurityCELCheck(a.(*PodSecurityCELCheck), b.(*api.PodSecurityCELCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityCELCheck)(nil), (*PodSecurityCELCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityCELCheck_To_v1beta1_PodSecurityCELCheck(a.(*api.PodSecurityCELCheck), b.(*PodSecurityCELCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityCheckExemption)(nil), (*api.PodSecurityCheckExemption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityCheckExemption_To_api_PodSecurityCheckExemption(a.(*PodSecurityCheckExemption), b.(*api.PodSecurityCheckExemption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityCheckExemption)(nil), (*PodSecurityCheckExemption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityCheckExemption_To_v1beta1_PodSecurityCheckExemption(a.(*api.PodSecurityCheckExemption), b.(*PodSecurityCheckExemption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityChecks)(nil), (*api.PodSecurityChecks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks(a.(*PodSecurityChecks), b.(*api.PodSecurityChecks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityChecks)(nil), (*PodSecurityChecks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks(a.(*api.PodSecurityChecks), b.(*PodSecurityChecks), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PodSecurityConfiguration)(nil), (*api.PodSecurityConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PodSecurityConfiguration_To_api_PodSecurityConfiguration(a.(*PodSecurityConfiguration), b.(*api.PodSecurityConfiguration), scope)
 	}); err != nil {
@@ -66,9 +117,181 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityImageCheck)(nil), (*api.PodSecurityImageCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityImageCheck_To_api_PodSecurityImageCheck(a.(*PodSecurityImageCheck), b.(*api.PodSecurityImageCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityImageCheck)(nil), (*PodSecurityImageCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityImageCheck_To_v1beta1_PodSecurityImageCheck(a.(*api.PodSecurityImageCheck), b.(*PodSecurityImageCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityLevel)(nil), (*api.PodSecurityLevel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityLevel_To_api_PodSecurityLevel(a.(*PodSecurityLevel), b.(*api.PodSecurityLevel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityLevel)(nil), (*PodSecurityLevel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityLevel_To_v1beta1_PodSecurityLevel(a.(*api.PodSecurityLevel), b.(*PodSecurityLevel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityResourceCheck)(nil), (*api.PodSecurityResourceCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityResourceCheck_To_api_PodSecurityResourceCheck(a.(*PodSecurityResourceCheck), b.(*api.PodSecurityResourceCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodSecurityResourceCheck)(nil), (*PodSecurityResourceCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodSecurityResourceCheck_To_v1beta1_PodSecurityResourceCheck(a.(*api.PodSecurityResourceCheck), b.(*PodSecurityResourceCheck), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(in *PodSecurityAllowListOverride, out *api.PodSecurityAllowListOverride, s conversion.Scope) error {
+	out.Add = *(*[]string)(unsafe.Pointer(&in.Add))
+	out.Remove = *(*[]string)(unsafe.Pointer(&in.Remove))
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(in *PodSecurityAllowListOverride, out *api.PodSecurityAllowListOverride, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(in, out, s)
+}
+
+func autoConvert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(in *api.PodSecurityAllowListOverride, out *PodSecurityAllowListOverride, s conversion.Scope) error {
+	out.Add = *(*[]string)(unsafe.Pointer(&in.Add))
+	out.Remove = *(*[]string)(unsafe.Pointer(&in.Remove))
+	return nil
+}
+
+// Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride is an autogenerated conversion function.
+func Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(in *api.PodSecurityAllowListOverride, out *PodSecurityAllowListOverride, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityAllowLists_To_api_PodSecurityAllowLists(in *PodSecurityAllowLists, out *api.PodSecurityAllowLists, s conversion.Scope) error {
+	out.Level = in.Level
+	if err := Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(&in.Sysctls, &out.Sysctls, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(&in.Capabilities, &out.Capabilities, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_PodSecurityAllowListOverride_To_api_PodSecurityAllowListOverride(&in.VolumeTypes, &out.VolumeTypes, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityAllowLists_To_api_PodSecurityAllowLists is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityAllowLists_To_api_PodSecurityAllowLists(in *PodSecurityAllowLists, out *api.PodSecurityAllowLists, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityAllowLists_To_api_PodSecurityAllowLists(in, out, s)
+}
+
+func autoConvert_api_PodSecurityAllowLists_To_v1beta1_PodSecurityAllowLists(in *api.PodSecurityAllowLists, out *PodSecurityAllowLists, s conversion.Scope) error {
+	out.Level = in.Level
+	if err := Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(&in.Sysctls, &out.Sysctls, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(&in.Capabilities, &out.Capabilities, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PodSecurityAllowListOverride_To_v1beta1_PodSecurityAllowListOverride(&in.VolumeTypes, &out.VolumeTypes, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_PodSecurityAllowLists_To_v1beta1_PodSecurityAllowLists is an autogenerated conversion function.
+func Convert_api_PodSecurityAllowLists_To_v1beta1_PodSecurityAllowLists(in *api.PodSecurityAllowLists, out *PodSecurityAllowLists, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityAllowLists_To_v1beta1_PodSecurityAllowLists(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityCELCheck_To_api_PodSecurityCELCheck(in *PodSecurityCELCheck, out *api.PodSecurityCELCheck, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Level = in.Level
+	out.MinimumVersion = in.MinimumVersion
+	out.Expression = in.Expression
+	out.Reason = in.Reason
+	out.MessageExpression = in.MessageExpression
+	out.FieldPath = in.FieldPath
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityCELCheck_To_api_PodSecurityCELCheck is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityCELCheck_To_api_PodSecurityCELCheck(in *PodSecurityCELCheck, out *api.PodSecurityCELCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityCELCheck_To_api_PodSecurityCELCheck(in, out, s)
+}
+
+func autoConvert_api_PodSecurityCELCheck_To_v1beta1_PodSecurityCELCheck(in *api.PodSecurityCELCheck, out *PodSecurityCELCheck, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Level = in.Level
+	out.MinimumVersion = in.MinimumVersion
+	out.Expression = in.Expression
+	out.Reason = in.Reason
+	out.MessageExpression = in.MessageExpression
+	out.FieldPath = in.FieldPath
+	return nil
+}
+
+// Convert_api_PodSecurityCELCheck_To_v1beta1_PodSecurityCELCheck is an autogenerated conversion function.
+func Convert_api_PodSecurityCELCheck_To_v1beta1_PodSecurityCELCheck(in *api.PodSecurityCELCheck, out *PodSecurityCELCheck, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityCELCheck_To_v1beta1_PodSecurityCELCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityCheckExemption_To_api_PodSecurityCheckExemption(in *PodSecurityCheckExemption, out *api.PodSecurityCheckExemption, s conversion.Scope) error {
+	out.CheckIDs = *(*[]string)(unsafe.Pointer(&in.CheckIDs))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityCheckExemption_To_api_PodSecurityCheckExemption is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityCheckExemption_To_api_PodSecurityCheckExemption(in *PodSecurityCheckExemption, out *api.PodSecurityCheckExemption, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityCheckExemption_To_api_PodSecurityCheckExemption(in, out, s)
+}
+
+func autoConvert_api_PodSecurityCheckExemption_To_v1beta1_PodSecurityCheckExemption(in *api.PodSecurityCheckExemption, out *PodSecurityCheckExemption, s conversion.Scope) error {
+	out.CheckIDs = *(*[]string)(unsafe.Pointer(&in.CheckIDs))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	return nil
+}
+
+// Convert_api_PodSecurityCheckExemption_To_v1beta1_PodSecurityCheckExemption is an autogenerated conversion function.
+func Convert_api_PodSecurityCheckExemption_To_v1beta1_PodSecurityCheckExemption(in *api.PodSecurityCheckExemption, out *PodSecurityCheckExemption, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityCheckExemption_To_v1beta1_PodSecurityCheckExemption(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks(in *PodSecurityChecks, out *api.PodSecurityChecks, s conversion.Scope) error {
+	out.CEL = *(*[]api.PodSecurityCELCheck)(unsafe.Pointer(&in.CEL))
+	out.Images = (*api.PodSecurityImageCheck)(unsafe.Pointer(in.Images))
+	out.Resources = (*api.PodSecurityResourceCheck)(unsafe.Pointer(in.Resources))
+	out.Hardened = *(*[]string)(unsafe.Pointer(&in.Hardened))
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks(in *PodSecurityChecks, out *api.PodSecurityChecks, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks(in, out, s)
+}
+
+func autoConvert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks(in *api.PodSecurityChecks, out *PodSecurityChecks, s conversion.Scope) error {
+	out.CEL = *(*[]PodSecurityCELCheck)(unsafe.Pointer(&in.CEL))
+	out.Images = (*PodSecurityImageCheck)(unsafe.Pointer(in.Images))
+	out.Resources = (*PodSecurityResourceCheck)(unsafe.Pointer(in.Resources))
+	out.Hardened = *(*[]string)(unsafe.Pointer(&in.Hardened))
 	return nil
 }
 
+// Convert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks is an autogenerated conversion function.
+func Convert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks(in *api.PodSecurityChecks, out *PodSecurityChecks, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks(in, out, s)
+}
+
 func autoConvert_v1beta1_PodSecurityConfiguration_To_api_PodSecurityConfiguration(in *PodSecurityConfiguration, out *api.PodSecurityConfiguration, s conversion.Scope) error {
 	if err := Convert_v1beta1_PodSecurityDefaults_To_api_PodSecurityDefaults(&in.Defaults, &out.Defaults, s); err != nil {
 		return err
@@ -76,6 +299,11 @@ func autoConvert_v1beta1_PodSecurityConfiguration_To_api_PodSecurityConfiguratio
 	if err := Convert_v1beta1_PodSecurityExemptions_To_api_PodSecurityExemptions(&in.Exemptions, &out.Exemptions, s); err != nil {
 		return err
 	}
+	out.Levels = *(*[]api.PodSecurityLevel)(unsafe.Pointer(&in.Levels))
+	if err := Convert_v1beta1_PodSecurityChecks_To_api_PodSecurityChecks(&in.Checks, &out.Checks, s); err != nil {
+		return err
+	}
+	out.AllowLists = *(*[]api.PodSecurityAllowLists)(unsafe.Pointer(&in.AllowLists))
 	return nil
 }
 
@@ -91,6 +319,11 @@ func autoConvert_api_PodSecurityConfiguration_To_v1beta1_PodSecurityConfiguratio
 	if err := Convert_api_PodSecurityExemptions_To_v1beta1_PodSecurityExemptions(&in.Exemptions, &out.Exemptions, s); err != nil {
 		return err
 	}
+	out.Levels = *(*[]PodSecurityLevel)(unsafe.Pointer(&in.Levels))
+	if err := Convert_api_PodSecurityChecks_To_v1beta1_PodSecurityChecks(&in.Checks, &out.Checks, s); err != nil {
+		return err
+	}
+	out.AllowLists = *(*[]PodSecurityAllowLists)(unsafe.Pointer(&in.AllowLists))
 	return nil
 }
 
@@ -131,8 +364,13 @@ func Convert_api_PodSecurityDefaults_To_v1beta1_PodSecurityDefaults(in *api.PodS
 
 func autoConvert_v1beta1_PodSecurityExemptions_To_api_PodSecurityExemptions(in *PodSecurityExemptions, out *api.PodSecurityExemptions, s conversion.Scope) error {
 	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.ServiceAccounts = *(*[]string)(unsafe.Pointer(&in.ServiceAccounts))
 	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespacePatterns = *(*[]string)(unsafe.Pointer(&in.NamespacePatterns))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.RuntimeClasses = *(*[]string)(unsafe.Pointer(&in.RuntimeClasses))
+	out.Checks = *(*[]api.PodSecurityCheckExemption)(unsafe.Pointer(&in.Checks))
 	return nil
 }
 
@@ -143,8 +381,13 @@ func Convert_v1beta1_PodSecurityExemptions_To_api_PodSecurityExemptions(in *PodS
 
 func autoConvert_api_PodSecurityExemptions_To_v1beta1_PodSecurityExemptions(in *api.PodSecurityExemptions, out *PodSecurityExemptions, s conversion.Scope) error {
 	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.ServiceAccounts = *(*[]string)(unsafe.Pointer(&in.ServiceAccounts))
 	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespacePatterns = *(*[]string)(unsafe.Pointer(&in.NamespacePatterns))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
 	out.RuntimeClasses = *(*[]string)(unsafe.Pointer(&in.RuntimeClasses))
+	out.Checks = *(*[]PodSecurityCheckExemption)(unsafe.Pointer(&in.Checks))
 	return nil
 }
 
@@ -152,3 +395,81 @@ func autoConvert_api_PodSecurityExemptions_To_v1beta1_PodSecurityExemptions(in *
 func Convert_api_PodSecurityExemptions_To_v1beta1_PodSecurityExemptions(in *api.PodSecurityExemptions, out *PodSecurityExemptions, s conversion.Scope) error {
 	return autoConvert_api_PodSecurityExemptions_To_v1beta1_PodSecurityExemptions(in, out, s)
 }
+
+func autoConvert_v1beta1_PodSecurityImageCheck_To_api_PodSecurityImageCheck(in *PodSecurityImageCheck, out *api.PodSecurityImageCheck, s conversion.Scope) error {
+	out.Level = in.Level
+	out.AllowedRegistries = *(*[]string)(unsafe.Pointer(&in.AllowedRegistries))
+	out.DisallowedTags = *(*[]string)(unsafe.Pointer(&in.DisallowedTags))
+	out.RequireDigest = in.RequireDigest
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityImageCheck_To_api_PodSecurityImageCheck is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityImageCheck_To_api_PodSecurityImageCheck(in *PodSecurityImageCheck, out *api.PodSecurityImageCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityImageCheck_To_api_PodSecurityImageCheck(in, out, s)
+}
+
+func autoConvert_api_PodSecurityImageCheck_To_v1beta1_PodSecurityImageCheck(in *api.PodSecurityImageCheck, out *PodSecurityImageCheck, s conversion.Scope) error {
+	out.Level = in.Level
+	out.AllowedRegistries = *(*[]string)(unsafe.Pointer(&in.AllowedRegistries))
+	out.DisallowedTags = *(*[]string)(unsafe.Pointer(&in.DisallowedTags))
+	out.RequireDigest = in.RequireDigest
+	return nil
+}
+
+// Convert_api_PodSecurityImageCheck_To_v1beta1_PodSecurityImageCheck is an autogenerated conversion function.
+func Convert_api_PodSecurityImageCheck_To_v1beta1_PodSecurityImageCheck(in *api.PodSecurityImageCheck, out *PodSecurityImageCheck, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityImageCheck_To_v1beta1_PodSecurityImageCheck(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityLevel_To_api_PodSecurityLevel(in *PodSecurityLevel, out *api.PodSecurityLevel, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Base = in.Base
+	out.AddChecks = *(*[]string)(unsafe.Pointer(&in.AddChecks))
+	out.RemoveChecks = *(*[]string)(unsafe.Pointer(&in.RemoveChecks))
+	out.After = in.After
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityLevel_To_api_PodSecurityLevel is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityLevel_To_api_PodSecurityLevel(in *PodSecurityLevel, out *api.PodSecurityLevel, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityLevel_To_api_PodSecurityLevel(in, out, s)
+}
+
+func autoConvert_api_PodSecurityLevel_To_v1beta1_PodSecurityLevel(in *api.PodSecurityLevel, out *PodSecurityLevel, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Base = in.Base
+	out.AddChecks = *(*[]string)(unsafe.Pointer(&in.AddChecks))
+	out.RemoveChecks = *(*[]string)(unsafe.Pointer(&in.RemoveChecks))
+	out.After = in.After
+	return nil
+}
+
+// Convert_api_PodSecurityLevel_To_v1beta1_PodSecurityLevel is an autogenerated conversion function.
+func Convert_api_PodSecurityLevel_To_v1beta1_PodSecurityLevel(in *api.PodSecurityLevel, out *PodSecurityLevel, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityLevel_To_v1beta1_PodSecurityLevel(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityResourceCheck_To_api_PodSecurityResourceCheck(in *PodSecurityResourceCheck, out *api.PodSecurityResourceCheck, s conversion.Scope) error {
+	out.Level = in.Level
+	out.Minimum = *(*map[string]string)(unsafe.Pointer(&in.Minimum))
+	out.Maximum = *(*map[string]string)(unsafe.Pointer(&in.Maximum))
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityResourceCheck_To_api_PodSecurityResourceCheck is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityResourceCheck_To_api_PodSecurityResourceCheck(in *PodSecurityResourceCheck, out *api.PodSecurityResourceCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityResourceCheck_To_api_PodSecurityResourceCheck(in, out, s)
+}
+
+func autoConvert_api_PodSecurityResourceCheck_To_v1beta1_PodSecurityResourceCheck(in *api.PodSecurityResourceCheck, out *PodSecurityResourceCheck, s conversion.Scope) error {
+	out.Level = in.Level
+	out.Minimum = *(*map[string]string)(unsafe.Pointer(&in.Minimum))
+	out.Maximum = *(*map[string]string)(unsafe.Pointer(&in.Maximum))
+	return nil
+}
+
+// Convert_api_PodSecurityResourceCheck_To_v1beta1_PodSecurityResourceCheck is an autogenerated conversion function.
+func Convert_api_PodSecurityResourceCheck_To_v1beta1_PodSecurityResourceCheck(in *api.PodSecurityResourceCheck, out *PodSecurityResourceCheck, s conversion.Scope) error {
+	return autoConvert_api_PodSecurityResourceCheck_To_v1beta1_PodSecurityResourceCheck(in, out, s)
+}
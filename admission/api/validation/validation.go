@@ -17,41 +17,73 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
+	"path"
+	"regexp"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	machinery "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	admissionapi "k8s.io/pod-security-admission/admission/api"
 	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
 )
 
 // ValidatePodSecurityConfiguration validates a given PodSecurityConfiguration.
 func ValidatePodSecurityConfiguration(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	customLevels := sets.New[string]()
+	for _, level := range configuration.Levels {
+		customLevels.Insert(level.Name)
+	}
+
 	// validate defaults
-	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "enforce"), configuration.Defaults.Enforce)...)
+	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "enforce"), configuration.Defaults.Enforce, customLevels)...)
 	allErrs = append(allErrs, validateVersion(field.NewPath("defaults", "enforce-version"), configuration.Defaults.EnforceVersion)...)
-	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "warn"), configuration.Defaults.Warn)...)
+	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "warn"), configuration.Defaults.Warn, customLevels)...)
 	allErrs = append(allErrs, validateVersion(field.NewPath("defaults", "warn-version"), configuration.Defaults.WarnVersion)...)
-	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "audit"), configuration.Defaults.Audit)...)
+	allErrs = append(allErrs, validateLevel(field.NewPath("defaults", "audit"), configuration.Defaults.Audit, customLevels)...)
 	allErrs = append(allErrs, validateVersion(field.NewPath("defaults", "audit-version"), configuration.Defaults.AuditVersion)...)
 
 	// validate exemptions
 	allErrs = append(allErrs, validateNamespaces(configuration)...)
+	allErrs = append(allErrs, validateNamespacePatterns(configuration)...)
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(configuration.Exemptions.NamespaceSelector,
+		metav1validation.LabelSelectorValidationOptions{}, field.NewPath("exemptions", "namespaceSelector"))...)
 	allErrs = append(allErrs, validateRuntimeClasses(configuration)...)
 	allErrs = append(allErrs, validateUsernames(configuration)...)
+	allErrs = append(allErrs, validateGroups(configuration)...)
+	allErrs = append(allErrs, validateServiceAccounts(configuration)...)
+	allErrs = append(allErrs, validateCheckExemptions(configuration)...)
+
+	// validate custom levels
+	allErrs = append(allErrs, validateLevels(configuration)...)
+
+	// validate allow-lists
+	allErrs = append(allErrs, validateAllowLists(configuration)...)
+
+	// validate checks
+	allErrs = append(allErrs, validateCELChecks(configuration)...)
+	allErrs = append(allErrs, validateImageCheck(configuration)...)
+	allErrs = append(allErrs, validateResourceCheck(configuration)...)
+	allErrs = append(allErrs, validateHardenedChecks(configuration)...)
 
 	return allErrs
 }
 
-// validateLevel validates a level
-func validateLevel(p *field.Path, value string) field.ErrorList {
+var builtinLevels = []string{string(api.LevelPrivileged), string(api.LevelBaseline), string(api.LevelRestricted)}
+
+// validateLevel validates a level, which may be a built-in level or one of the custom levels
+func validateLevel(p *field.Path, value string, customLevels sets.Set[string]) field.ErrorList {
 	errs := field.ErrorList{}
-	_, err := api.ParseLevel(value)
-	if err != nil {
-		errs = append(errs, field.Invalid(p, value, err.Error()))
+	if !sets.New(builtinLevels...).Has(value) && !customLevels.Has(value) {
+		valid := append(append([]string{}, builtinLevels...), sets.List(customLevels)...)
+		errs = append(errs, field.Invalid(p, value, "must be one of "+strings.Join(valid, ", ")))
 	}
 	return errs
 }
@@ -86,6 +118,30 @@ func validateNamespaces(configuration *admissionapi.PodSecurityConfiguration) fi
 	return errs
 }
 
+func validateNamespacePatterns(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	validSet := sets.NewString()
+	for i, pattern := range configuration.Exemptions.NamespacePatterns {
+		if pattern == "" {
+			p := field.NewPath("exemptions", "namespacePatterns").Index(i)
+			errs = append(errs, field.Invalid(p, pattern, "pattern must not be empty"))
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			p := field.NewPath("exemptions", "namespacePatterns").Index(i)
+			errs = append(errs, field.Invalid(p, pattern, err.Error()))
+			continue
+		}
+		if validSet.Has(pattern) {
+			p := field.NewPath("exemptions", "namespacePatterns").Index(i)
+			errs = append(errs, field.Duplicate(p, pattern))
+			continue
+		}
+		validSet.Insert(pattern)
+	}
+	return errs
+}
+
 func validateRuntimeClasses(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
 	errs := field.ErrorList{}
 	validSet := sets.NewString()
@@ -125,3 +181,336 @@ func validateUsernames(configuration *admissionapi.PodSecurityConfiguration) fie
 
 	return errs
 }
+
+func validateGroups(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	validSet := sets.NewString()
+	for i, group := range configuration.Exemptions.Groups {
+		if group == "" {
+			path := field.NewPath("exemptions", "groups").Index(i)
+			errs = append(errs, field.Invalid(path, group, "group must not be empty"))
+			continue
+		}
+		if validSet.Has(group) {
+			path := field.NewPath("exemptions", "groups").Index(i)
+			errs = append(errs, field.Duplicate(path, group))
+			continue
+		}
+		validSet.Insert(group)
+	}
+	return errs
+}
+
+func validateServiceAccounts(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	validSet := sets.NewString()
+	for i, sa := range configuration.Exemptions.ServiceAccounts {
+		if _, _, err := admissionapi.SplitServiceAccountPattern(sa); err != nil {
+			path := field.NewPath("exemptions", "serviceAccounts").Index(i)
+			errs = append(errs, field.Invalid(path, sa, err.Error()))
+			continue
+		}
+		if validSet.Has(sa) {
+			path := field.NewPath("exemptions", "serviceAccounts").Index(i)
+			errs = append(errs, field.Duplicate(path, sa))
+			continue
+		}
+		validSet.Insert(sa)
+	}
+	return errs
+}
+
+func validateCheckExemptions(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	knownIDs := knownCheckIDs(configuration)
+	for i, exemption := range configuration.Exemptions.Checks {
+		path := field.NewPath("exemptions", "checks").Index(i)
+		if len(exemption.CheckIDs) == 0 {
+			errs = append(errs, field.Required(path.Child("checkIDs"), "at least one check ID is required"))
+		}
+		validSet := sets.NewString()
+		for j, id := range exemption.CheckIDs {
+			if id == "" {
+				errs = append(errs, field.Invalid(path.Child("checkIDs").Index(j), id, "check ID must not be empty"))
+				continue
+			}
+			if validSet.Has(id) {
+				errs = append(errs, field.Duplicate(path.Child("checkIDs").Index(j), id))
+				continue
+			}
+			if !knownIDs.Has(id) {
+				errs = append(errs, field.NotSupported(path.Child("checkIDs").Index(j), id, sets.List(knownIDs)))
+				continue
+			}
+			validSet.Insert(id)
+		}
+		if exemption.NamespaceSelector == nil && exemption.PodSelector == nil {
+			errs = append(errs, field.Required(path, "one of namespaceSelector or podSelector is required"))
+		}
+		opts := metav1validation.LabelSelectorValidationOptions{}
+		errs = append(errs, metav1validation.ValidateLabelSelector(exemption.NamespaceSelector, opts, path.Child("namespaceSelector"))...)
+		errs = append(errs, metav1validation.ValidateLabelSelector(exemption.PodSelector, opts, path.Child("podSelector"))...)
+	}
+	return errs
+}
+
+// knownCheckIDs returns the IDs of the checks that can be evaluated with the configuration: the built-in,
+// optional and registered checks, and the CEL checks of the configuration.
+func knownCheckIDs(configuration *admissionapi.PodSecurityConfiguration) sets.Set[string] {
+	ids := sets.New[string]()
+	checks := append(append(append(policy.DefaultChecks(), policy.ExperimentalChecks()...), policy.HardenedChecks()...), policy.CustomChecks()...)
+	checks = append(checks, policy.CheckImages(policy.ImagePolicy{}), policy.CheckResources(policy.ResourcePolicy{}))
+	for _, check := range checks {
+		ids.Insert(string(check.ID))
+	}
+	for _, check := range configuration.Checks.CEL {
+		ids.Insert(check.ID)
+	}
+	return ids
+}
+
+func validateLevels(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	builtins := sets.New(builtinLevels...)
+	declared := sets.New[string]()
+	afters := map[string]string{}
+	for i, level := range configuration.Levels {
+		path := field.NewPath("levels").Index(i)
+		if level.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), ""))
+		} else if msgs := utilvalidation.IsValidLabelValue(level.Name); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("name"), level.Name, strings.Join(msgs, ", ")))
+		} else if builtins.Has(level.Name) {
+			errs = append(errs, field.Invalid(path.Child("name"), level.Name, "must not be a built-in level"))
+		} else if declared.Has(level.Name) {
+			errs = append(errs, field.Duplicate(path.Child("name"), level.Name))
+		}
+
+		if !builtins.Has(level.Base) {
+			errs = append(errs, field.NotSupported(path.Child("base"), level.Base, builtinLevels))
+		}
+		after := level.After
+		if after == "" {
+			after = level.Base
+		}
+		if !builtins.Has(after) && !declared.Has(after) {
+			if level.After != "" {
+				errs = append(errs, field.Invalid(path.Child("after"), level.After, "must be a built-in level or a level declared earlier"))
+			}
+		} else if other, ok := afters[after]; ok {
+			errs = append(errs, field.Invalid(path.Child("after"), after, fmt.Sprintf("level %s is already ordered after %s", other, after)))
+		} else {
+			afters[after] = level.Name
+		}
+
+		added := sets.New[string]()
+		for j, id := range level.AddChecks {
+			if id == "" {
+				errs = append(errs, field.Invalid(path.Child("addChecks").Index(j), id, "check ID must not be empty"))
+			} else if added.Has(id) {
+				errs = append(errs, field.Duplicate(path.Child("addChecks").Index(j), id))
+			}
+			added.Insert(id)
+		}
+		removed := sets.New[string]()
+		for j, id := range level.RemoveChecks {
+			if id == "" {
+				errs = append(errs, field.Invalid(path.Child("removeChecks").Index(j), id, "check ID must not be empty"))
+			} else if removed.Has(id) {
+				errs = append(errs, field.Duplicate(path.Child("removeChecks").Index(j), id))
+			} else if added.Has(id) {
+				errs = append(errs, field.Invalid(path.Child("removeChecks").Index(j), id, "check must not be both added and removed"))
+			}
+			removed.Insert(id)
+		}
+
+		declared.Insert(level.Name)
+	}
+	return errs
+}
+
+func validateCELChecks(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	ids := sets.New[string]()
+	for i, check := range configuration.Checks.CEL {
+		path := field.NewPath("checks", "cel").Index(i)
+		if check.ID == "" {
+			errs = append(errs, field.Required(path.Child("id"), ""))
+		} else if ids.Has(check.ID) {
+			errs = append(errs, field.Duplicate(path.Child("id"), check.ID))
+		}
+		ids.Insert(check.ID)
+		if check.Level != string(api.LevelBaseline) && check.Level != string(api.LevelRestricted) {
+			errs = append(errs, field.NotSupported(path.Child("level"), check.Level, []string{string(api.LevelBaseline), string(api.LevelRestricted)}))
+		}
+		if check.MinimumVersion == "" {
+			errs = append(errs, field.Required(path.Child("minimumVersion"), ""))
+		} else if v, err := api.ParseVersion(check.MinimumVersion); err != nil {
+			errs = append(errs, field.Invalid(path.Child("minimumVersion"), check.MinimumVersion, err.Error()))
+		} else if v.Latest() {
+			errs = append(errs, field.Invalid(path.Child("minimumVersion"), check.MinimumVersion, `must not be "latest"`))
+		}
+		if check.Expression == "" {
+			errs = append(errs, field.Required(path.Child("expression"), ""))
+		}
+		if check.Reason == "" {
+			errs = append(errs, field.Required(path.Child("reason"), ""))
+		}
+	}
+	return errs
+}
+
+func validateImageCheck(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	check := configuration.Checks.Images
+	if check == nil {
+		return errs
+	}
+	path := field.NewPath("checks", "images")
+	if check.Level != string(api.LevelBaseline) && check.Level != string(api.LevelRestricted) {
+		errs = append(errs, field.NotSupported(path.Child("level"), check.Level, []string{string(api.LevelBaseline), string(api.LevelRestricted)}))
+	}
+	for i, registry := range check.AllowedRegistries {
+		if registry == "" {
+			errs = append(errs, field.Required(path.Child("allowedRegistries").Index(i), ""))
+		}
+	}
+	for i, tag := range check.DisallowedTags {
+		if tag == "" {
+			errs = append(errs, field.Required(path.Child("disallowedTags").Index(i), ""))
+		}
+	}
+	if len(check.AllowedRegistries) == 0 && len(check.DisallowedTags) == 0 && !check.RequireDigest {
+		errs = append(errs, field.Required(path, "must set allowedRegistries, disallowedTags or requireDigest"))
+	}
+	return errs
+}
+
+func validateResourceCheck(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	check := configuration.Checks.Resources
+	if check == nil {
+		return errs
+	}
+	path := field.NewPath("checks", "resources")
+	if check.Level != string(api.LevelBaseline) && check.Level != string(api.LevelRestricted) {
+		errs = append(errs, field.NotSupported(path.Child("level"), check.Level, []string{string(api.LevelBaseline), string(api.LevelRestricted)}))
+	}
+	validateBounds := func(p *field.Path, bounds map[string]string) map[string]*resource.Quantity {
+		quantities := map[string]*resource.Quantity{}
+		for _, name := range sets.List(sets.KeySet(bounds)) {
+			if name != "cpu" && name != "memory" {
+				errs = append(errs, field.NotSupported(p, name, []string{"cpu", "memory"}))
+				continue
+			}
+			quantity, err := resource.ParseQuantity(bounds[name])
+			if err != nil {
+				errs = append(errs, field.Invalid(p.Key(name), bounds[name], err.Error()))
+				continue
+			}
+			quantities[name] = &quantity
+		}
+		return quantities
+	}
+	minimum := validateBounds(path.Child("minimum"), check.Minimum)
+	maximum := validateBounds(path.Child("maximum"), check.Maximum)
+	for _, name := range sets.List(sets.KeySet(minimum)) {
+		if min, max := minimum[name], maximum[name]; max != nil && min.Cmp(*max) > 0 {
+			errs = append(errs, field.Invalid(path.Child("minimum").Key(name), check.Minimum[name], "must not exceed the maximum"))
+		}
+	}
+	return errs
+}
+
+func validateHardenedChecks(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	var validIDs []string
+	for _, check := range policy.HardenedChecks() {
+		validIDs = append(validIDs, string(check.ID))
+	}
+	ids := sets.New[string]()
+	for i, id := range configuration.Checks.Hardened {
+		path := field.NewPath("checks", "hardened").Index(i)
+		if !sets.New(validIDs...).Has(id) {
+			errs = append(errs, field.NotSupported(path, id, validIDs))
+		} else if ids.Has(id) {
+			errs = append(errs, field.Duplicate(path, id))
+		}
+		ids.Insert(id)
+	}
+	return errs
+}
+
+var (
+	sysctlNameRegexp     = regexp.MustCompile(`^([a-z0-9]([-_a-z0-9]*[a-z0-9])?[./])*[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`)
+	capabilityNameRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+func validateAllowLists(configuration *admissionapi.PodSecurityConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	customLevels := sets.New[string]()
+	for _, level := range configuration.Levels {
+		customLevels.Insert(level.Name)
+	}
+	volumeTypes := sets.New(policy.VolumeTypes()...)
+
+	levels := sets.New[string]()
+	for i, lists := range configuration.AllowLists {
+		path := field.NewPath("allowLists").Index(i)
+		if lists.Level == string(api.LevelPrivileged) {
+			errs = append(errs, field.Invalid(path.Child("level"), lists.Level, "privileged has no checks to override"))
+		} else if levelErrs := validateLevel(path.Child("level"), lists.Level, customLevels); len(levelErrs) > 0 {
+			errs = append(errs, levelErrs...)
+		} else if levels.Has(lists.Level) {
+			errs = append(errs, field.Duplicate(path.Child("level"), lists.Level))
+		}
+		levels.Insert(lists.Level)
+
+		errs = append(errs, validateAllowListOverride(path.Child("sysctls"), lists.Sysctls, func(p *field.Path, value string) *field.Error {
+			if len(value) > 253 || !sysctlNameRegexp.MatchString(value) {
+				return field.Invalid(p, value, "must be a valid sysctl name, e.g. net.core.somaxconn")
+			}
+			return nil
+		})...)
+		errs = append(errs, validateAllowListOverride(path.Child("capabilities"), lists.Capabilities, func(p *field.Path, value string) *field.Error {
+			if !capabilityNameRegexp.MatchString(value) {
+				return field.Invalid(p, value, "must be a capability name without the CAP_ prefix, e.g. NET_RAW")
+			}
+			return nil
+		})...)
+		errs = append(errs, validateAllowListOverride(path.Child("volumeTypes"), lists.VolumeTypes, func(p *field.Path, value string) *field.Error {
+			if !volumeTypes.Has(value) {
+				return field.NotSupported(p, value, sets.List(volumeTypes))
+			}
+			return nil
+		})...)
+	}
+	return errs
+}
+
+// validateAllowListOverride validates the values of an allow-list override, which must not be
+// both added and removed.
+func validateAllowListOverride(p *field.Path, override admissionapi.PodSecurityAllowListOverride, validateValue func(*field.Path, string) *field.Error) field.ErrorList {
+	errs := field.ErrorList{}
+	added := sets.New[string]()
+	for i, value := range override.Add {
+		if err := validateValue(p.Child("add").Index(i), value); err != nil {
+			errs = append(errs, err)
+		} else if added.Has(value) {
+			errs = append(errs, field.Duplicate(p.Child("add").Index(i), value))
+		}
+		added.Insert(value)
+	}
+	removed := sets.New[string]()
+	for i, value := range override.Remove {
+		if err := validateValue(p.Child("remove").Index(i), value); err != nil {
+			errs = append(errs, err)
+		} else if removed.Has(value) {
+			errs = append(errs, field.Duplicate(p.Child("remove").Index(i), value))
+		} else if added.Has(value) {
+			errs = append(errs, field.Invalid(p.Child("remove").Index(i), value, "must not be both added and removed"))
+		}
+		removed.Insert(value)
+	}
+	return errs
+}
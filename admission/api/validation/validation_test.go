@@ -19,6 +19,7 @@ package validation
 import (
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/admission/api"
 )
@@ -54,6 +55,13 @@ func TestValidatePodSecurityConfiguration(t *testing.T) {
 				field.Duplicate(exemptionsPath("runtimeClasses", 4), validValue),
 				field.Invalid(exemptionsPath("usernames", 0), invalidValueEmpty, "..."),
 				field.Duplicate(exemptionsPath("usernames", 2), validValue),
+				field.Invalid(exemptionsPath("groups", 0), invalidValueEmpty, "..."),
+				field.Duplicate(exemptionsPath("groups", 2), validValue),
+				field.Invalid(exemptionsPath("serviceAccounts", 0), invalidValueEmpty, "..."),
+				field.Invalid(exemptionsPath("serviceAccounts", 1), "ci-*:*", "..."),
+				field.Invalid(exemptionsPath("serviceAccounts", 2), "system:serviceaccount:ci-*", "..."),
+				field.Invalid(exemptionsPath("serviceAccounts", 3), "system:serviceaccount:ci-[:*", "..."),
+				field.Duplicate(exemptionsPath("serviceAccounts", 5), "system:serviceaccount:ci-*:*"),
 			},
 			configuration: api.PodSecurityConfiguration{
 				Defaults: api.PodSecurityDefaults{
@@ -85,6 +93,19 @@ func TestValidatePodSecurityConfiguration(t *testing.T) {
 						validValue,
 						validValue,
 					},
+					Groups: []string{
+						invalidValueEmpty,
+						validValue,
+						validValue,
+					},
+					ServiceAccounts: []string{
+						invalidValueEmpty,
+						"ci-*:*",
+						"system:serviceaccount:ci-*",
+						"system:serviceaccount:ci-[:*",
+						"system:serviceaccount:ci-*:*",
+						"system:serviceaccount:ci-*:*",
+					},
 				},
 			},
 		},
@@ -109,6 +130,255 @@ func TestValidatePodSecurityConfiguration(t *testing.T) {
 				Exemptions: api.PodSecurityExemptions{},
 			},
 		},
+		// namespace patterns and selector
+		{
+			expectedErrList: field.ErrorList{
+				field.Invalid(exemptionsPath("namespacePatterns", 0), invalidValueEmpty, "..."),
+				field.Invalid(exemptionsPath("namespacePatterns", 1), "preview-[", "..."),
+				field.Duplicate(exemptionsPath("namespacePatterns", 3), "preview-*"),
+				field.Invalid(field.NewPath("exemptions", "namespaceSelector", "matchLabels"), invalidValueChars, "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Exemptions: api.PodSecurityExemptions{
+					NamespacePatterns: []string{invalidValueEmpty, "preview-[", "preview-*", "preview-*"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": invalidValueChars}},
+				},
+			},
+		},
+		// check exemptions
+		{
+			expectedErrList: field.ErrorList{
+				field.Invalid(checkExemptionsPath(0).Child("checkIDs").Index(1), invalidValueEmpty, "..."),
+				field.Duplicate(checkExemptionsPath(0).Child("checkIDs").Index(2), "hostPorts"),
+				field.Required(checkExemptionsPath(1).Child("checkIDs"), ""),
+				field.Required(checkExemptionsPath(1), ""),
+				field.NotSupported[string](checkExemptionsPath(2).Child("checkIDs").Index(1), "runAsNonroot", nil),
+				field.Invalid(checkExemptionsPath(2).Child("podSelector", "matchLabels"), invalidValueChars, "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Exemptions: api.PodSecurityExemptions{
+					Checks: []api.PodSecurityCheckExemption{
+						{
+							CheckIDs:          []string{"hostPorts", invalidValueEmpty, "hostPorts"},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "infra"}},
+						},
+						{},
+						{
+							CheckIDs:    []string{"sysctls", "runAsNonroot"},
+							PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": invalidValueChars}},
+						},
+					},
+				},
+			},
+		},
+		// custom levels
+		{
+			expectedErrList: field.ErrorList{
+				field.Invalid(defaultsPath("warn"), "undeclared", "..."),
+				field.Required(levelsPath(1).Child("name"), ""),
+				field.Invalid(levelsPath(2).Child("name"), "baseline", "..."),
+				field.NotSupported[string](levelsPath(2).Child("base"), "strict", nil),
+				field.Duplicate(levelsPath(3).Child("name"), "restricted-plus"),
+				field.Invalid(levelsPath(3).Child("after"), "restricted", "..."),
+				field.Invalid(levelsPath(4).Child("name"), invalidValueChars, "..."),
+				field.Invalid(levelsPath(4).Child("after"), "later", "..."),
+				field.Invalid(levelsPath(4).Child("addChecks").Index(0), invalidValueEmpty, "..."),
+				field.Duplicate(levelsPath(4).Child("addChecks").Index(2), "hostPorts"),
+				field.Invalid(levelsPath(4).Child("removeChecks").Index(0), "hostPorts", "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "baseline-minus-hostPorts",
+					EnforceVersion: "latest",
+					Audit:          "restricted-plus",
+					AuditVersion:   "latest",
+					Warn:           "undeclared",
+					WarnVersion:    "latest",
+				},
+				Levels: []api.PodSecurityLevel{
+					{Name: "restricted-plus", Base: "restricted", AddChecks: []string{"images"}},
+					{Base: "baseline", After: "restricted-plus"},
+					{Name: "baseline", Base: "strict"},
+					{Name: "restricted-plus", Base: "restricted"},
+					{Name: invalidValueChars, Base: "baseline", After: "later", AddChecks: []string{invalidValueEmpty, "hostPorts", "hostPorts"}, RemoveChecks: []string{"hostPorts"}},
+					{Name: "baseline-minus-hostPorts", Base: "baseline", After: "privileged", RemoveChecks: []string{"hostPorts"}},
+				},
+			},
+		},
+		// allow-lists
+		{
+			expectedErrList: field.ErrorList{
+				field.Invalid(allowListsPath(1).Child("level"), "privileged", "..."),
+				field.Invalid(allowListsPath(2).Child("sysctls", "add").Index(0), "Net.Core", "..."),
+				field.Duplicate(allowListsPath(2).Child("sysctls", "add").Index(2), "net.core.somaxconn"),
+				field.Invalid(allowListsPath(2).Child("sysctls", "remove").Index(0), "net.core.somaxconn", "..."),
+				field.Invalid(allowListsPath(2).Child("capabilities", "add").Index(0), "CAP_net_raw", "..."),
+				field.NotSupported[string](allowListsPath(2).Child("volumeTypes", "remove").Index(0), "nfs4", nil),
+				field.Duplicate(allowListsPath(3).Child("level"), "restricted"),
+				field.Invalid(allowListsPath(4).Child("level"), "strict", "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Levels: []api.PodSecurityLevel{
+					{Name: "restricted-plus", Base: "restricted"},
+				},
+				AllowLists: []api.PodSecurityAllowLists{
+					{Level: "baseline", Sysctls: api.PodSecurityAllowListOverride{Add: []string{"net.core.somaxconn"}}, VolumeTypes: api.PodSecurityAllowListOverride{Add: []string{"nfs"}}},
+					{Level: "privileged"},
+					{
+						Level:        "restricted",
+						Sysctls:      api.PodSecurityAllowListOverride{Add: []string{"Net.Core", "net.core.somaxconn", "net.core.somaxconn"}, Remove: []string{"net.core.somaxconn"}},
+						Capabilities: api.PodSecurityAllowListOverride{Add: []string{"CAP_net_raw", "NET_RAW"}, Remove: []string{"CHOWN"}},
+						VolumeTypes:  api.PodSecurityAllowListOverride{Remove: []string{"nfs4", "csi"}},
+					},
+					{Level: "restricted"},
+					{Level: "strict"},
+					{Level: "restricted-plus", VolumeTypes: api.PodSecurityAllowListOverride{Add: []string{"nfs"}}},
+				},
+			},
+		},
+		// CEL checks
+		{
+			expectedErrList: field.ErrorList{
+				field.Required(celChecksPath(1).Child("id"), ""),
+				field.NotSupported[string](celChecksPath(1).Child("level"), "privileged", nil),
+				field.Required(celChecksPath(1).Child("minimumVersion"), ""),
+				field.Required(celChecksPath(1).Child("expression"), ""),
+				field.Required(celChecksPath(1).Child("reason"), ""),
+				field.Duplicate(celChecksPath(2).Child("id"), "trustedRegistry"),
+				field.Invalid(celChecksPath(2).Child("minimumVersion"), "latest", "..."),
+				field.Invalid(celChecksPath(3).Child("minimumVersion"), "1.0", "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					CEL: []api.PodSecurityCELCheck{
+						{ID: "trustedRegistry", Level: "restricted", MinimumVersion: "v1.0", Expression: "true", Reason: "untrusted registry"},
+						{Level: "privileged"},
+						{ID: "trustedRegistry", Level: "baseline", MinimumVersion: "latest", Expression: "true", Reason: "untrusted registry"},
+						{ID: "other", Level: "baseline", MinimumVersion: "1.0", Expression: "true", Reason: "other"},
+					},
+				},
+			},
+		},
+		// image check
+		{
+			expectedErrList: field.ErrorList{
+				field.NotSupported[string](imageCheckPath.Child("level"), "", nil),
+				field.Required(imageCheckPath.Child("allowedRegistries").Index(1), ""),
+				field.Required(imageCheckPath.Child("disallowedTags").Index(0), ""),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Images: &api.PodSecurityImageCheck{
+						AllowedRegistries: []string{"registry.example.com/", ""},
+						DisallowedTags:    []string{""},
+					},
+				},
+			},
+		},
+		{
+			expectedErrList: field.ErrorList{
+				field.Required(imageCheckPath, ""),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Images: &api.PodSecurityImageCheck{Level: "restricted"},
+				},
+			},
+		},
+		// hardened checks
+		{
+			expectedErrList: field.ErrorList{
+				field.NotSupported[string](field.NewPath("checks", "hardened").Index(1), "readOnlyRootFS", nil),
+				field.Duplicate(field.NewPath("checks", "hardened").Index(2), "readOnlyRootFilesystem"),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Hardened: []string{"readOnlyRootFilesystem", "readOnlyRootFS", "readOnlyRootFilesystem", "automountServiceAccountToken"},
+				},
+			},
+		},
+		// resource check
+		{
+			expectedErrList: field.ErrorList{
+				field.NotSupported[string](resourceCheckPath.Child("level"), "privileged", nil),
+				field.Invalid(resourceCheckPath.Child("minimum").Key("cpu"), "a lot", "..."),
+				field.NotSupported[string](resourceCheckPath.Child("minimum"), "gpu", nil),
+				field.Invalid(resourceCheckPath.Child("minimum").Key("memory"), "2Gi", "..."),
+			},
+			configuration: api.PodSecurityConfiguration{
+				Defaults: api.PodSecurityDefaults{
+					Enforce:        "privileged",
+					EnforceVersion: "latest",
+					Audit:          "privileged",
+					AuditVersion:   "latest",
+					Warn:           "privileged",
+					WarnVersion:    "latest",
+				},
+				Checks: api.PodSecurityChecks{
+					Resources: &api.PodSecurityResourceCheck{
+						Level:   "privileged",
+						Minimum: map[string]string{"cpu": "a lot", "gpu": "1", "memory": "2Gi"},
+						Maximum: map[string]string{"cpu": "4", "memory": "1Gi"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -141,3 +411,29 @@ func defaultsPath(child string) *field.Path {
 func exemptionsPath(child string, i int) *field.Path {
 	return field.NewPath("exemptions", child).Index(i)
 }
+
+// checkExemptionsPath returns the path of the given check exemption
+func checkExemptionsPath(i int) *field.Path {
+	return field.NewPath("exemptions", "checks").Index(i)
+}
+
+// levelsPath returns the path of the given custom level
+func levelsPath(i int) *field.Path {
+	return field.NewPath("levels").Index(i)
+}
+
+// imageCheckPath is the path of the image check
+var imageCheckPath = field.NewPath("checks", "images")
+
+// resourceCheckPath is the path of the resource check
+var resourceCheckPath = field.NewPath("checks", "resources")
+
+// allowListsPath returns the path of the given allow-lists
+func allowListsPath(i int) *field.Path {
+	return field.NewPath("allowLists").Index(i)
+}
+
+// celChecksPath returns the path of the given CEL check
+func celChecksPath(i int) *field.Path {
+	return field.NewPath("checks", "cel").Index(i)
+}
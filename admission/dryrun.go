@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// DryRunReport is the result of evaluating a proposed policy against the existing workloads in a namespace.
+type DryRunReport struct {
+	Namespace string `json:"namespace"`
+	Enforce   string `json:"enforce"`
+	Audit     string `json:"audit"`
+	Warn      string `json:"warn"`
+	// Exempt is set if the namespace is exempt from Pod Security. Its workloads are evaluated regardless.
+	Exempt bool `json:"exempt,omitempty"`
+	// Workloads holds an entry for every pod and pod controller in the namespace, ordered by kind and name.
+	Workloads []DryRunWorkload `json:"workloads"`
+	// Skipped is the number of pods that were not evaluated by EvaluateNamespacePods, because the context was done
+	// or the maximum number of pods to check was reached. Workloads is incomplete if it is set.
+	Skipped int `json:"skipped,omitempty"`
+}
+
+// Violating returns the workloads that would be denied by the proposed enforce policy.
+func (r *DryRunReport) Violating() []DryRunWorkload {
+	var violating []DryRunWorkload
+	for _, w := range r.Workloads {
+		if len(w.Enforce) > 0 {
+			violating = append(violating, w)
+		}
+	}
+	return violating
+}
+
+// DryRunWorkload holds the violations of a single pod or pod controller.
+type DryRunWorkload struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Owner is the kind and name of the controller of the workload, if any.
+	Owner string `json:"owner,omitempty"`
+	// Exempt is set for workloads using an exempt runtime class, which are not evaluated.
+	Exempt bool `json:"exempt,omitempty"`
+	// ExemptChecks lists the checks waived for the workload by check exemptions.
+	ExemptChecks []string `json:"exemptChecks,omitempty"`
+
+	Enforce []DryRunViolation `json:"enforce,omitempty"`
+	Audit   []DryRunViolation `json:"audit,omitempty"`
+	Warn    []DryRunViolation `json:"warn,omitempty"`
+}
+
+// DryRunViolation is a failed check.
+type DryRunViolation struct {
+	Check  string `json:"check,omitempty"`
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// DryRunNamespacePolicy evaluates the proposed policy against every pod and pod controller in the namespace.
+// Unlike EvaluatePodsInNamespace, all workloads are evaluated, limited only by the context deadline,
+// and the full results are returned. Pod controllers are only evaluated if a PodControllerLister is set.
+func (a *Admission) DryRunNamespacePolicy(ctx context.Context, namespace string, proposed api.Policy) (*DryRunReport, error) {
+	ns, err := a.NamespaceGetter.GetNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := a.PodLister.ListPods(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var controllers []runtime.Object
+	if a.PodControllerLister != nil {
+		controllers, err = a.PodControllerLister.ListPodControllers(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pod controllers: %w", err)
+		}
+	}
+
+	report := &DryRunReport{
+		Namespace: namespace,
+		Enforce:   proposed.Enforce.String(),
+		Audit:     proposed.Audit.String(),
+		Warn:      proposed.Warn.String(),
+		Exempt:    a.exemptNamespace(namespace) || a.exemptNamespaceLabels(ns.Labels),
+		Workloads: make([]DryRunWorkload, 0, len(pods)+len(controllers)),
+	}
+	podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+	for _, pod := range pods {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Workloads = append(report.Workloads, a.dryRunWorkload(ns.Labels, proposed, podGVK, pod, &pod.ObjectMeta, &pod.Spec))
+	}
+	for _, obj := range controllers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "failed to access pod controller metadata")
+			continue
+		}
+		podMetadata, podSpec, err := a.PodSpecExtractor.ExtractPodSpec(obj)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "failed to extract pod spec", "name", accessor.GetName())
+			continue
+		}
+		if podSpec == nil {
+			continue
+		}
+		report.Workloads = append(report.Workloads, a.dryRunWorkload(ns.Labels, proposed, obj.GetObjectKind().GroupVersionKind(), accessor, podMetadata, podSpec))
+	}
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		wi, wj := report.Workloads[i], report.Workloads[j]
+		if wi.Kind != wj.Kind {
+			return wi.Kind < wj.Kind
+		}
+		return wi.Name < wj.Name
+	})
+	return report, nil
+}
+
+// EvaluateNamespacePods evaluates the enforce, audit and warn policy of the namespace against its pods, and
+// returns the results in the same form as DryRunNamespacePolicy. Pods are evaluated in the order used by
+// EvaluatePodsInNamespace, with pods of distinct controllers first and pods with exempt runtime classes skipped,
+// until the context is done or the same maximum number of pods is reached. The pods left are counted in Skipped.
+// Pods of exempt namespaces are not evaluated.
+func (a *Admission) EvaluateNamespacePods(ctx context.Context, namespace string) (*DryRunReport, error) {
+	ns, err := a.NamespaceGetter.GetNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	// Invalid labels are defaulted as they are when admitting pods.
+	nsPolicy, _ := a.PolicyToEvaluate(ns.Labels)
+	report := &DryRunReport{
+		Namespace: namespace,
+		Enforce:   nsPolicy.Enforce.String(),
+		Audit:     nsPolicy.Audit.String(),
+		Warn:      nsPolicy.Warn.String(),
+		Exempt:    a.exemptNamespace(namespace) || a.exemptNamespaceLabels(ns.Labels),
+	}
+	if report.Exempt {
+		return report, nil
+	}
+	pods, err := a.PodLister.ListPods(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	prioritizedPods := a.prioritizePods(pods)
+	if len(prioritizedPods) > a.namespaceMaxPodsToCheck {
+		report.Skipped = len(prioritizedPods) - a.namespaceMaxPodsToCheck
+		prioritizedPods = prioritizedPods[0:a.namespaceMaxPodsToCheck]
+	}
+
+	podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+	for i, pod := range prioritizedPods {
+		if ctx.Err() != nil {
+			report.Skipped += len(prioritizedPods) - i
+			break
+		}
+		report.Workloads = append(report.Workloads, a.dryRunWorkload(ns.Labels, nsPolicy, podGVK, pod, &pod.ObjectMeta, &pod.Spec))
+	}
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		return report.Workloads[i].Name < report.Workloads[j].Name
+	})
+	return report, nil
+}
+
+// dryRunWorkload evaluates each distinct level and version of the proposed policy against a workload.
+func (a *Admission) dryRunWorkload(nsLabels map[string]string, proposed api.Policy, gvk schema.GroupVersionKind, obj metav1.Object, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec) DryRunWorkload {
+	w := DryRunWorkload{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+	}
+	if ref := metav1.GetControllerOfNoCopy(obj); ref != nil {
+		w.Owner = ref.Kind + "/" + ref.Name
+	}
+	if a.exemptRuntimeClass(podSpec.RuntimeClassName) {
+		w.Exempt = true
+		return w
+	}
+
+	var podLabels map[string]string
+	if podMetadata != nil {
+		podLabels = podMetadata.Labels
+	}
+	exemptChecks := a.exemptChecks(nsLabels, podLabels)
+	if exemptChecks.Len() > 0 {
+		w.ExemptChecks = sets.List(exemptChecks)
+	}
+
+	cachedViolations := make(map[api.LevelVersion][]DryRunViolation)
+	evaluate := func(lv api.LevelVersion) []DryRunViolation {
+		if violations, ok := cachedViolations[lv]; ok {
+			return violations
+		}
+		result := policy.AggregateCheckResults(withoutExemptChecks(a.Evaluator.EvaluatePod(lv, podMetadata, podSpec), exemptChecks))
+		violations := dryRunViolations(&result)
+		cachedViolations[lv] = violations
+		return violations
+	}
+	w.Enforce = evaluate(proposed.Enforce)
+	w.Audit = evaluate(proposed.Audit)
+	w.Warn = evaluate(proposed.Warn)
+	return w
+}
+
+func dryRunViolations(result *policy.AggregateCheckResult) []DryRunViolation {
+	var violations []DryRunViolation
+	for i, r := range result.ForbiddenResults {
+		violations = append(violations, DryRunViolation{
+			Check:  string(r.CheckID),
+			Reason: result.ForbiddenReasons[i],
+			Detail: result.ForbiddenDetails[i],
+		})
+	}
+	return violations
+}
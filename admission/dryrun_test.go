@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/test"
+	"k8s.io/utils/ptr"
+)
+
+func TestDryRunNamespacePolicy(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	const namespace = "test-ns"
+
+	restrictedPod, err := test.GetMinimalValidPod(api.LevelRestricted, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	restrictedPod.Name = "restricted"
+	restrictedPod.Namespace = namespace
+
+	baselinePod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	baselinePod.Name = "baseline"
+	baselinePod.Namespace = namespace
+
+	privilegedPod := baselinePod.DeepCopy()
+	privilegedPod.Name = "privileged-abcde"
+	privilegedPod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+	privilegedPod.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "privileged",
+		UID:        "uid",
+		Controller: ptr.To(true),
+	}}
+
+	exemptPod := privilegedPod.DeepCopy()
+	exemptPod.Name = "exempt"
+	exemptPod.OwnerReferences = nil
+	exemptPod.Spec.RuntimeClassName = ptr.To("exempt-runtimeclass")
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "privileged", Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{ObjectMeta: privilegedPod.ObjectMeta, Spec: privilegedPod.Spec},
+		},
+	}
+
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: map[string]string{api.EnforceLevelLabel: string(api.LevelPrivileged)},
+		}},
+		restrictedPod, baselinePod, privilegedPod, exemptPod, deployment,
+	)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err)
+	config.Exemptions.RuntimeClasses = []string{"exempt-runtimeclass"}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	a := &Admission{
+		Configuration:       config,
+		Evaluator:           evaluator,
+		Metrics:             &FakeRecorder{},
+		NamespaceGetter:     NamespaceGetterFromClient(client),
+		PodLister:           PodListerFromClient(client),
+		PodControllerLister: PodControllerListerFromClient(client),
+	}
+	require.NoError(t, a.CompleteConfiguration())
+	require.NoError(t, a.ValidateConfiguration())
+
+	baseline := api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}
+	restricted := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+	report, err := a.DryRunNamespacePolicy(ctx, namespace, api.Policy{Enforce: baseline, Audit: restricted, Warn: restricted})
+	require.NoError(t, err)
+
+	privilegedViolation := DryRunViolation{
+		Check:  "privileged",
+		Reason: "privileged",
+		Detail: `container "container1" must not set securityContext.privileged=true`,
+	}
+	assert.Equal(t, namespace, report.Namespace)
+	assert.Equal(t, "baseline:latest", report.Enforce)
+	assert.Equal(t, "restricted:latest", report.Audit)
+	assert.False(t, report.Exempt)
+
+	var summary []string
+	for _, w := range report.Workloads {
+		summary = append(summary, w.Kind+"/"+w.Name)
+	}
+	assert.Equal(t, []string{"Deployment/privileged", "Pod/baseline", "Pod/exempt", "Pod/privileged-abcde", "Pod/restricted"}, summary)
+
+	deploymentResult := report.Workloads[0]
+	assert.Equal(t, "apps/v1", deploymentResult.APIVersion)
+	assert.Equal(t, []DryRunViolation{privilegedViolation}, deploymentResult.Enforce)
+
+	baselineResult := report.Workloads[1]
+	assert.Empty(t, baselineResult.Enforce)
+	assert.Len(t, baselineResult.Audit, 4, "restricted violations")
+	assert.Equal(t, baselineResult.Audit, baselineResult.Warn)
+
+	assert.True(t, report.Workloads[2].Exempt)
+	assert.Empty(t, report.Workloads[2].Enforce)
+
+	podResult := report.Workloads[3]
+	assert.Equal(t, "ReplicaSet/privileged", podResult.Owner)
+	assert.Equal(t, []DryRunViolation{privilegedViolation}, podResult.Enforce)
+
+	assert.Empty(t, report.Workloads[4].Audit)
+
+	var violating []string
+	for _, w := range report.Violating() {
+		violating = append(violating, w.Kind+"/"+w.Name)
+	}
+	assert.Equal(t, []string{"Deployment/privileged", "Pod/privileged-abcde"}, violating)
+
+	_, err = a.DryRunNamespacePolicy(ctx, "missing", api.Policy{Enforce: baseline, Audit: baseline, Warn: baseline})
+	assert.Error(t, err)
+}
+
+func TestEvaluateNamespacePods(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	baselinePod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	baselinePod.Name = "baseline"
+	baselinePod.Namespace = "test-ns"
+
+	privilegedPod := baselinePod.DeepCopy()
+	privilegedPod.Name = "privileged"
+	privilegedPod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+	exemptPod := privilegedPod.DeepCopy()
+	exemptPod.Name = "exempt"
+	exemptPod.Spec.RuntimeClassName = ptr.To("exempt-runtimeclass")
+
+	exemptNamespacePod := privilegedPod.DeepCopy()
+	exemptNamespacePod.Namespace = "exempt-ns"
+
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "test-ns",
+			Labels: map[string]string{
+				api.EnforceLevelLabel: string(api.LevelBaseline),
+				api.WarnLevelLabel:    string(api.LevelRestricted),
+			},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "exempt-ns",
+			Labels: map[string]string{api.EnforceLevelLabel: string(api.LevelBaseline)},
+		}},
+		baselinePod, privilegedPod, exemptPod, exemptNamespacePod,
+	)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err)
+	config.Exemptions.RuntimeClasses = []string{"exempt-runtimeclass"}
+	config.Exemptions.Namespaces = []string{"exempt-ns"}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	a := &Admission{
+		Configuration:   config,
+		Evaluator:       evaluator,
+		Metrics:         &FakeRecorder{},
+		NamespaceGetter: NamespaceGetterFromClient(client),
+		PodLister:       PodListerFromClient(client),
+	}
+	require.NoError(t, a.CompleteConfiguration())
+	require.NoError(t, a.ValidateConfiguration())
+
+	report, err := a.EvaluateNamespacePods(ctx, "test-ns")
+	require.NoError(t, err)
+	assert.Equal(t, "baseline:latest", report.Enforce)
+	assert.Equal(t, "privileged:latest", report.Audit)
+	assert.Equal(t, "restricted:latest", report.Warn)
+	require.Len(t, report.Workloads, 2, "pods with exempt runtime classes are skipped")
+	assert.Equal(t, "baseline", report.Workloads[0].Name)
+	assert.Empty(t, report.Workloads[0].Enforce)
+	assert.NotEmpty(t, report.Workloads[0].Warn)
+	assert.Equal(t, "privileged", report.Workloads[1].Name)
+	assert.Equal(t, []DryRunViolation{{
+		Check:  "privileged",
+		Reason: "privileged",
+		Detail: `container "container1" must not set securityContext.privileged=true`,
+	}}, report.Workloads[1].Enforce)
+
+	// Pods of distinct controllers are evaluated first, up to the maximum number of pods to check.
+	a.namespaceMaxPodsToCheck = 1
+	report, err = a.EvaluateNamespacePods(ctx, "test-ns")
+	require.NoError(t, err)
+	require.Len(t, report.Workloads, 1)
+	assert.Equal(t, 1, report.Skipped)
+
+	// Pods left when the context is done are counted as skipped.
+	a.namespaceMaxPodsToCheck = 3000
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	report, err = a.EvaluateNamespacePods(canceledCtx, "test-ns")
+	require.NoError(t, err)
+	assert.Empty(t, report.Workloads)
+	assert.Equal(t, 2, report.Skipped)
+
+	report, err = a.EvaluateNamespacePods(ctx, "exempt-ns")
+	require.NoError(t, err)
+	assert.True(t, report.Exempt)
+	assert.Empty(t, report.Workloads)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/pod-security-admission/metrics"
+)
+
+// ViolationEventReason is the reason of the Events recorded for policy violations.
+const ViolationEventReason = "PodSecurityViolation"
+
+// eventViolationSink records an Event against the pod controllers violating the audit or warn policy.
+type eventViolationSink struct {
+	recorder record.EventRecorder
+}
+
+// NewEventViolationSink returns a ViolationSink recording a Warning Event against every pod controller violating
+// the audit or warn policy of its namespace, so that violations are shown by kubectl describe. Pods, objects
+// created with generateName, and dry run requests are skipped. The recorder is expected to deduplicate and rate-limit the Events,
+// as the recorders of a record.EventBroadcaster do.
+func NewEventViolationSink(recorder record.EventRecorder) ViolationSink {
+	return &eventViolationSink{recorder: recorder}
+}
+
+func (s *eventViolationSink) RecordViolation(_ context.Context, record ViolationRecord) {
+	if record.Mode != metrics.ModeAudit && record.Mode != metrics.ModeWarn {
+		return
+	}
+	if record.Resource == podsResource.String() || record.Name == "" || record.DryRun {
+		return
+	}
+	// kubectl describe finds the Events of an object by its UID.
+	ref := &corev1.ObjectReference{
+		APIVersion: record.APIVersion,
+		Kind:       record.Kind,
+		Namespace:  record.Namespace,
+		Name:       record.Name,
+		UID:        record.UID,
+	}
+	s.recorder.Event(ref, corev1.EventTypeWarning, ViolationEventReason, fmt.Sprintf(
+		"%s: would violate PodSecurity \"%s:%s\": %s",
+		record.Mode,
+		record.Level,
+		record.Version,
+		strings.Join(record.Reasons, ", "),
+	))
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/test"
+	"k8s.io/utils/ptr"
+)
+
+func TestEventViolationSink(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	recorder := &capturingEventRecorder{}
+
+	a := &Admission{
+		PodLister:     &testPodLister{},
+		Evaluator:     evaluator,
+		Configuration: config,
+		Metrics:       &FakeRecorder{},
+		ViolationSink: NewEventViolationSink(recorder),
+		NamespaceGetter: testNamespaceGetter{
+			"test-ns": {ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ns",
+				Labels: map[string]string{
+					api.AuditLevelLabel: string(api.LevelBaseline),
+					api.WarnLevelLabel:  string(api.LevelBaseline),
+				},
+			}},
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, a.ValidateConfiguration(), "ValidateConfiguration()")
+
+	pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "test-ns", UID: "deployment-uid"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec},
+		},
+	}
+	pod.Name = "test-pod"
+
+	for _, attrs := range []*api.AttributesRecord{
+		{
+			Name:      deployment.Name,
+			Namespace: "test-ns",
+			Kind:      appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			Resource:  appsv1.SchemeGroupVersion.WithResource("deployments"),
+			Operation: admissionv1.Create,
+			Object:    deployment,
+		},
+		{
+			// Dry runs are skipped.
+			Name:      deployment.Name,
+			Namespace: "test-ns",
+			Kind:      appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			Resource:  appsv1.SchemeGroupVersion.WithResource("deployments"),
+			Operation: admissionv1.Update,
+			Object:    deployment,
+			OldObject: deployment,
+			DryRun:    true,
+		},
+		{
+			// Pods are skipped.
+			Name:      pod.Name,
+			Namespace: "test-ns",
+			Kind:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Resource:  schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Operation: admissionv1.Create,
+			Object:    pod,
+		},
+	} {
+		a.Validate(ctx, attrs)
+	}
+
+	ref := corev1.ObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "test-ns",
+		Name:       "test-deployment",
+		UID:        "deployment-uid",
+	}
+	assert.Equal(t, []capturedEvent{
+		{ref: ref, event: `Warning PodSecurityViolation audit: would violate PodSecurity "baseline:latest": privileged`},
+		{ref: ref, event: `Warning PodSecurityViolation warn: would violate PodSecurity "baseline:latest": privileged`},
+	}, recorder.events)
+}
+
+type capturedEvent struct {
+	ref   corev1.ObjectReference
+	event string
+}
+
+// capturingEventRecorder records the Events recorded against object references, with their references.
+type capturingEventRecorder struct {
+	events []capturedEvent
+}
+
+func (r *capturingEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.events = append(r.events, capturedEvent{
+		ref:   *object.(*corev1.ObjectReference),
+		event: fmt.Sprintf("%s %s %s", eventtype, reason, message),
+	})
+}
+
+func (r *capturingEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *capturingEventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/klog/v2"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/utils/ptr"
+)
+
+var jsonPatchType = admissionv1.PatchTypeJSONPatch
+
+// remediation mutates a pod spec so that the check it is registered for passes.
+type remediation struct {
+	// remediate mutates the pod spec in place.
+	remediate func(podSpec *corev1.PodSpec)
+	// linuxOnly indicates the remediation sets fields that are not allowed on Windows pods.
+	linuxOnly bool
+}
+
+// remediations are the remediations applied by Mutate, keyed by the ID of the check they satisfy.
+var remediations = map[policy.CheckID]remediation{
+	"allowPrivilegeEscalation":  {remediate: remediateAllowPrivilegeEscalation, linuxOnly: true},
+	"capabilities_restricted":   {remediate: remediateCapabilities, linuxOnly: true},
+	"runAsNonRoot":              {remediate: remediateRunAsNonRoot},
+	"seccompProfile_baseline":   {remediate: remediateSeccompProfile, linuxOnly: true},
+	"seccompProfile_restricted": {remediate: remediateSeccompProfile, linuxOnly: true},
+}
+
+// Mutate remediates a pod create request, or a pod controller create or update request, towards the
+// enforce policy for the namespace. Only failing checks with a known remediation are fixed, and the
+// remediations are returned as a JSON patch. Requests are never denied; that is left to Validate.
+// The returned response may be shared between evaluations and must not be mutated.
+func (a *Admission) Mutate(ctx context.Context, attrs api.Attributes) *admissionv1.AdmissionResponse {
+	// short-circuit on subresources and resources or operations that can't be remediated
+	if attrs.GetSubresource() != "" {
+		return sharedAllowedResponse
+	}
+	switch gr := attrs.GetResource().GroupResource(); {
+	case gr == podsResource:
+		// the security context of an existing pod is immutable
+		if attrs.GetOperation() != admissionv1.Create {
+			return sharedAllowedResponse
+		}
+	case a.PodSpecExtractor.HasPodSpec(gr):
+		if attrs.GetOperation() != admissionv1.Create && attrs.GetOperation() != admissionv1.Update {
+			return sharedAllowedResponse
+		}
+	default:
+		return sharedAllowedResponse
+	}
+	// short-circuit on exempt namespaces and users
+	if a.exemptNamespace(attrs.GetNamespace()) || a.exemptUser(attrs.GetUserName(), attrs.GetUserGroups()) {
+		return sharedAllowedResponse
+	}
+
+	namespace, err := a.NamespaceGetter.GetNamespace(ctx, attrs.GetNamespace())
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to fetch pod namespace", "namespace", attrs.GetNamespace())
+		a.Metrics.RecordError(false, attrs)
+		response := allowedResponse()
+		response.AuditAnnotations = map[string]string{
+			"error": fmt.Sprintf("failed to lookup namespace %q: %v", attrs.GetNamespace(), err),
+		}
+		return response
+	}
+	if a.exemptNamespaceLabels(namespace.Labels) {
+		return sharedAllowedResponse
+	}
+	nsPolicy, nsPolicyErrs := a.PolicyToEvaluate(namespace.Labels)
+	if len(nsPolicyErrs) > 0 || nsPolicy.Enforce.Level == api.LevelPrivileged {
+		return sharedAllowedResponse
+	}
+
+	obj, err := attrs.GetObject()
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to decode object")
+		a.Metrics.RecordError(false, attrs)
+		response := allowedResponse()
+		response.AuditAnnotations = map[string]string{
+			"error": fmt.Sprintf("failed to decode object: %v", err),
+		}
+		return response
+	}
+	mutated := obj.DeepCopyObject()
+	podMetadata, podSpec, err := a.PodSpecExtractor.ExtractPodSpec(mutated)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to extract pod spec")
+		a.Metrics.RecordError(false, attrs)
+		response := allowedResponse()
+		response.AuditAnnotations = map[string]string{
+			"error": fmt.Sprintf("failed to extract pod template: %v", err),
+		}
+		return response
+	}
+	if podSpec == nil || a.exemptRuntimeClass(podSpec.RuntimeClassName) {
+		return sharedAllowedResponse
+	}
+
+	var podLabels map[string]string
+	if podMetadata != nil {
+		podLabels = podMetadata.Labels
+	}
+	remediated := a.remediatePod(nsPolicy.Enforce, podMetadata, podSpec, a.exemptChecks(namespace.Labels, podLabels))
+	if len(remediated) == 0 {
+		return sharedAllowedResponse
+	}
+
+	patch, err := createJSONPatch(obj, mutated)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to create remediation patch")
+		a.Metrics.RecordError(false, attrs)
+		response := allowedResponse()
+		response.AuditAnnotations = map[string]string{
+			"error": fmt.Sprintf("failed to create remediation patch: %v", err),
+		}
+		return response
+	}
+
+	response := allowedResponse()
+	response.Patch = patch
+	response.PatchType = &jsonPatchType
+	response.AuditAnnotations = map[string]string{
+		api.EnforcedPolicyAnnotationKey:   nsPolicy.Enforce.String(),
+		api.RemediatedChecksAnnotationKey: strings.Join(remediated, ","),
+	}
+	response.Warnings = []string{fmt.Sprintf(
+		"modified to comply with PodSecurity %q: remediated %s",
+		nsPolicy.Enforce.String(),
+		strings.Join(remediated, ", "),
+	)}
+	return response
+}
+
+// createJSONPatch returns the JSON patch that transforms the serialization of original into that of modified.
+func createJSONPatch(original, modified runtime.Object) ([]byte, error) {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+	modifiedJSON, err := json.Marshal(modified)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := jsonpatch.CreatePatch(originalJSON, modifiedJSON)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(operations)
+}
+
+// remediatePod applies the remediations for the checks of the given level and version that the pod fails,
+// skipping exempt checks. The pod spec is mutated in place. The IDs of the remediated checks are returned in order.
+func (a *Admission) remediatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, exemptChecks sets.Set[string]) []string {
+	windows := podSpec.OS != nil && podSpec.OS.Name == corev1.Windows
+	remediated := sets.New[string]()
+	for _, result := range a.Evaluator.EvaluatePod(lv, podMetadata, podSpec) {
+		if result.Allowed || exemptChecks.Has(string(result.CheckID)) {
+			continue
+		}
+		r, ok := remediations[result.CheckID]
+		if !ok || (windows && r.linuxOnly) {
+			continue
+		}
+		r.remediate(podSpec)
+		remediated.Insert(string(result.CheckID))
+	}
+	return sets.List(remediated)
+}
+
+// visitContainers invokes the visitor function for every container in the given pod spec.
+func visitContainers(podSpec *corev1.PodSpec, visitor func(container *corev1.Container)) {
+	for i := range podSpec.InitContainers {
+		visitor(&podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		visitor(&podSpec.Containers[i])
+	}
+	for i := range podSpec.EphemeralContainers {
+		visitor((*corev1.Container)(&podSpec.EphemeralContainers[i].EphemeralContainerCommon))
+	}
+}
+
+func ensureSecurityContext(container *corev1.Container) *corev1.SecurityContext {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	return container.SecurityContext
+}
+
+func ensurePodSecurityContext(podSpec *corev1.PodSpec) *corev1.PodSecurityContext {
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	return podSpec.SecurityContext
+}
+
+// remediateAllowPrivilegeEscalation sets allowPrivilegeEscalation=false on every container.
+func remediateAllowPrivilegeEscalation(podSpec *corev1.PodSpec) {
+	visitContainers(podSpec, func(container *corev1.Container) {
+		if sc := container.SecurityContext; sc == nil || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			ensureSecurityContext(container).AllowPrivilegeEscalation = ptr.To(false)
+		}
+	})
+}
+
+// remediateCapabilities drops ALL capabilities from every container,
+// and removes added capabilities other than NET_BIND_SERVICE.
+func remediateCapabilities(podSpec *corev1.PodSpec) {
+	visitContainers(podSpec, func(container *corev1.Container) {
+		sc := ensureSecurityContext(container)
+		if sc.Capabilities == nil {
+			sc.Capabilities = &corev1.Capabilities{}
+		}
+		caps := sc.Capabilities
+		droppedAll := false
+		for _, c := range caps.Drop {
+			if c == "ALL" {
+				droppedAll = true
+				break
+			}
+		}
+		if !droppedAll {
+			caps.Drop = append(caps.Drop, "ALL")
+		}
+		allowedAdd := make([]corev1.Capability, 0, len(caps.Add))
+		for _, c := range caps.Add {
+			if c == "NET_BIND_SERVICE" {
+				allowedAdd = append(allowedAdd, c)
+			}
+		}
+		if len(allowedAdd) != len(caps.Add) {
+			caps.Add = allowedAdd
+		}
+	})
+}
+
+// remediateRunAsNonRoot sets runAsNonRoot=true on the pod, and on containers that set runAsNonRoot=false.
+func remediateRunAsNonRoot(podSpec *corev1.PodSpec) {
+	if sc := podSpec.SecurityContext; sc == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+		ensurePodSecurityContext(podSpec).RunAsNonRoot = ptr.To(true)
+	}
+	visitContainers(podSpec, func(container *corev1.Container) {
+		if sc := container.SecurityContext; sc != nil && sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot {
+			sc.RunAsNonRoot = ptr.To(true)
+		}
+	})
+}
+
+// remediateSeccompProfile sets the RuntimeDefault seccomp profile on the pod if it does not set a valid profile,
+// and on containers that set a profile other than RuntimeDefault or Localhost.
+func remediateSeccompProfile(podSpec *corev1.PodSpec) {
+	if sc := podSpec.SecurityContext; sc == nil || sc.SeccompProfile == nil || !validSeccompProfile(sc.SeccompProfile) {
+		ensurePodSecurityContext(podSpec).SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	visitContainers(podSpec, func(container *corev1.Container) {
+		if sc := container.SecurityContext; sc != nil && sc.SeccompProfile != nil && !validSeccompProfile(sc.SeccompProfile) {
+			sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		}
+	})
+}
+
+func validSeccompProfile(profile *corev1.SeccompProfile) bool {
+	return profile.Type == corev1.SeccompProfileTypeRuntimeDefault || profile.Type == corev1.SeccompProfileTypeLocalhost
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2/ktesting"
+	admissionapi "k8s.io/pod-security-admission/admission/api"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/test"
+	"k8s.io/utils/ptr"
+)
+
+func TestMutate(t *testing.T) {
+	const (
+		restrictedNs = "restricted-ns"
+		baselineNs   = "baseline-ns"
+		privilegedNs = "privileged-ns"
+		waivedNs     = "waived-ns"
+	)
+
+	baselinePod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	baselinePod.Name = "test-pod"
+
+	restrictedPod, err := test.GetMinimalValidPod(api.LevelRestricted, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	restrictedPod.Name = "test-pod"
+
+	unconfinedPod := baselinePod.DeepCopy()
+	unconfinedPod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+		Capabilities:   &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE", "SYS_TIME"}},
+		RunAsNonRoot:   ptr.To(false),
+	}
+
+	windowsPod := baselinePod.DeepCopy()
+	windowsPod.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+
+	privilegedPod := baselinePod.DeepCopy()
+	privilegedPod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: baselinePod.ObjectMeta,
+				Spec:       baselinePod.Spec,
+			},
+		},
+	}
+
+	makeNs := func(name string, level api.Level) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{api.EnforceLevelLabel: string(level)},
+		}}
+	}
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err)
+	config.Exemptions.Checks = []admissionapi.PodSecurityCheckExemption{{
+		CheckIDs:          []string{"runAsNonRoot"},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": waivedNs}},
+	}}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	waived := makeNs(waivedNs, api.LevelRestricted)
+	waived.Labels["kubernetes.io/metadata.name"] = waivedNs
+	a := &Admission{
+		Configuration: config,
+		Evaluator:     evaluator,
+		Metrics:       &FakeRecorder{},
+		PodLister:     &testPodLister{},
+		NamespaceGetter: testNamespaceGetter{
+			restrictedNs: makeNs(restrictedNs, api.LevelRestricted),
+			baselineNs:   makeNs(baselineNs, api.LevelBaseline),
+			privilegedNs: makeNs(privilegedNs, api.LevelPrivileged),
+			waivedNs:     waived,
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration())
+	require.NoError(t, a.ValidateConfiguration())
+
+	podResource := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	deploymentResource := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	testCases := []struct {
+		name        string
+		namespace   string
+		resource    schema.GroupVersionResource
+		subresource string
+		operation   admissionv1.Operation
+		obj         runtime.Object
+
+		expectRemediated string
+		// expectAllowed is whether the patched object is allowed by the enforce policy
+		expectAllowed bool
+	}{
+		{
+			name:             "baseline pod in restricted namespace",
+			namespace:        restrictedNs,
+			resource:         podResource,
+			obj:              baselinePod,
+			expectRemediated: "allowPrivilegeEscalation,capabilities_restricted,runAsNonRoot,seccompProfile_restricted",
+			expectAllowed:    true,
+		},
+		{
+			name:             "explicitly bad fields",
+			namespace:        restrictedNs,
+			resource:         podResource,
+			obj:              unconfinedPod,
+			expectRemediated: "allowPrivilegeEscalation,capabilities_restricted,runAsNonRoot,seccompProfile_restricted",
+			expectAllowed:    true,
+		},
+		{
+			name:             "deployment in restricted namespace",
+			namespace:        restrictedNs,
+			resource:         deploymentResource,
+			obj:              deployment,
+			expectRemediated: "allowPrivilegeEscalation,capabilities_restricted,runAsNonRoot,seccompProfile_restricted",
+			expectAllowed:    true,
+		},
+		{
+			name:             "windows pod",
+			namespace:        restrictedNs,
+			resource:         podResource,
+			obj:              windowsPod,
+			expectRemediated: "runAsNonRoot",
+			expectAllowed:    true,
+		},
+		{
+			name:             "waived check",
+			namespace:        waivedNs,
+			resource:         podResource,
+			obj:              baselinePod,
+			expectRemediated: "allowPrivilegeEscalation,capabilities_restricted,seccompProfile_restricted",
+		},
+		{
+			name:             "unremediable check",
+			namespace:        baselineNs,
+			resource:         podResource,
+			obj:              privilegedPod,
+			expectRemediated: "",
+		},
+		{
+			name:      "compliant pod",
+			namespace: restrictedNs,
+			resource:  podResource,
+			obj:       restrictedPod,
+		},
+		{
+			name:      "privileged namespace",
+			namespace: privilegedNs,
+			resource:  podResource,
+			obj:       baselinePod,
+		},
+		{
+			name:      "pod update",
+			namespace: restrictedNs,
+			resource:  podResource,
+			operation: admissionv1.Update,
+			obj:       baselinePod,
+		},
+		{
+			name:        "subresource",
+			namespace:   restrictedNs,
+			resource:    podResource,
+			subresource: "ephemeralcontainers",
+			obj:         baselinePod,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ctx := ktesting.NewTestContext(t)
+			operation := tc.operation
+			if operation == "" {
+				operation = admissionv1.Create
+			}
+			obj := tc.obj.DeepCopyObject()
+			attrs := &api.AttributesRecord{
+				Name:        "test",
+				Namespace:   tc.namespace,
+				Resource:    tc.resource,
+				Subresource: tc.subresource,
+				Operation:   operation,
+				Object:      obj,
+			}
+			response := a.Mutate(ctx, attrs)
+			require.True(t, response.Allowed)
+			assert.Equal(t, tc.obj, obj, "object was modified")
+			assert.Equal(t, tc.expectRemediated, response.AuditAnnotations[api.RemediatedChecksAnnotationKey])
+			if tc.expectRemediated == "" {
+				assert.Nil(t, response.Patch)
+				return
+			}
+			require.NotNil(t, response.PatchType)
+			assert.Equal(t, admissionv1.PatchTypeJSONPatch, *response.PatchType)
+
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			require.NoError(t, err)
+			original, err := json.Marshal(obj)
+			require.NoError(t, err)
+			patched, err := patch.Apply(original)
+			require.NoError(t, err)
+			patchedObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+			require.NoError(t, json.Unmarshal(patched, patchedObj))
+
+			podMetadata, podSpec, err := DefaultPodSpecExtractor{}.ExtractPodSpec(patchedObj)
+			require.NoError(t, err)
+			result := policy.AggregateCheckResults(evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}, podMetadata, podSpec))
+			assert.Equal(t, tc.expectAllowed, result.Allowed, result.ForbiddenDetail())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodControllerLister lists the objects embedding a pod spec in a namespace, other than pods.
+type PodControllerLister interface {
+	// ListPodControllers returns the pod controllers in the namespace, with their kind set.
+	ListPodControllers(ctx context.Context, namespace string) ([]runtime.Object, error)
+}
+
+// PodControllerListerFromClient returns a PodControllerLister that does live lists of the
+// pod controller resources handled by DefaultPodSpecExtractor.
+func PodControllerListerFromClient(client kubernetes.Interface) PodControllerLister {
+	return &clientPodControllerLister{client}
+}
+
+type clientPodControllerLister struct {
+	client kubernetes.Interface
+}
+
+func (p *clientPodControllerLister) ListPodControllers(ctx context.Context, namespace string) ([]runtime.Object, error) {
+	var objs []runtime.Object
+	add := func(gvk schema.GroupVersionKind, obj runtime.Object) {
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		objs = append(objs, obj)
+	}
+	opts := metav1.ListOptions{}
+
+	podTemplates, err := p.client.CoreV1().PodTemplates(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range podTemplates.Items {
+		add(corev1.SchemeGroupVersion.WithKind("PodTemplate"), &podTemplates.Items[i])
+	}
+	replicationControllers, err := p.client.CoreV1().ReplicationControllers(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range replicationControllers.Items {
+		add(corev1.SchemeGroupVersion.WithKind("ReplicationController"), &replicationControllers.Items[i])
+	}
+	replicaSets, err := p.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range replicaSets.Items {
+		add(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"), &replicaSets.Items[i])
+	}
+	deployments, err := p.client.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		add(appsv1.SchemeGroupVersion.WithKind("Deployment"), &deployments.Items[i])
+	}
+	statefulSets, err := p.client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		add(appsv1.SchemeGroupVersion.WithKind("StatefulSet"), &statefulSets.Items[i])
+	}
+	daemonSets, err := p.client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		add(appsv1.SchemeGroupVersion.WithKind("DaemonSet"), &daemonSets.Items[i])
+	}
+	jobs, err := p.client.BatchV1().Jobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs.Items {
+		add(batchv1.SchemeGroupVersion.WithKind("Job"), &jobs.Items[i])
+	}
+	cronJobs, err := p.client.BatchV1().CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cronJobs.Items {
+		add(batchv1.SchemeGroupVersion.WithKind("CronJob"), &cronJobs.Items[i])
+	}
+	return objs, nil
+}
@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
 )
 
 var (
@@ -49,11 +50,37 @@ func allowedResponse() *admissionv1.AdmissionResponse {
 }
 
 // forbiddenResponse is the response used when the admission decision is deny for policy violations.
-func forbiddenResponse(attrs api.Attributes, err error) *admissionv1.AdmissionResponse {
+// The optional causes are attached to the status details to identify the offending fields.
+func forbiddenResponse(attrs api.Attributes, err error, causes ...metav1.StatusCause) *admissionv1.AdmissionResponse {
+	status := apierrors.NewForbidden(attrs.GetResource().GroupResource(), attrs.GetName(), err).ErrStatus
+	if len(causes) > 0 {
+		status.Details.Causes = causes
+	}
 	return &admissionv1.AdmissionResponse{
 		Allowed: false,
-		Result:  &apierrors.NewForbidden(attrs.GetResource().GroupResource(), attrs.GetName(), err).ErrStatus,
+		Result:  &status,
+	}
+}
+
+// violationCauses converts the field errors of the forbidden checks into status causes.
+// Causes are ordered by the forbidden checks of the result, then by the order of the field errors.
+func violationCauses(result *policy.AggregateCheckResult) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for i, r := range result.ForbiddenResults {
+		if r.ErrList == nil {
+			continue
+		}
+		reason := result.ForbiddenReasons[i]
+		for _, err := range *r.ErrList {
+			// Example message: unrestricted capabilities: Forbidden: ["BAR","FOO"]
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseType(err.Type),
+				Message: fmt.Sprintf("%s: %s", reason, policy.FieldErrorBody(err)),
+				Field:   err.Field,
+			})
+		}
 	}
+	return causes
 }
 
 // invalidResponse is the response used for namespace requests when namespace labels are invalid.
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/metrics"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// ViolationRecord is a structured record of a pod violating the policy of an enforce, audit or warn mode.
+type ViolationRecord struct {
+	// Time is when the violation was found.
+	Time time.Time `json:"time"`
+	// Mode is the mode whose policy was violated.
+	Mode metrics.Mode `json:"mode"`
+	// Operation is the operation of the request, such as CREATE or UPDATE.
+	Operation string `json:"operation"`
+	// Resource is the resource of the request, such as pods or deployments.apps.
+	Resource string `json:"resource"`
+	// Subresource is the subresource of the request, if any.
+	Subresource string `json:"subresource,omitempty"`
+	// APIVersion and Kind are the type of the object of the request.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Namespace and Name identify the object of the request. Name is empty for objects created with generateName.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+	// UID is the UID of the object of the request, if known.
+	UID types.UID `json:"uid,omitempty"`
+	// DryRun is set if the request is a dry run, whose object is not persisted.
+	DryRun bool `json:"dryRun,omitempty"`
+	// User is the name of the user making the request.
+	User string `json:"user"`
+	// Level and Version are the policy that was violated.
+	Level   api.Level `json:"level"`
+	Version string    `json:"version"`
+	// Checks are the IDs of the checks that forbade the pod.
+	Checks []policy.CheckID `json:"checks"`
+	// Reasons are the forbidden reasons of the checks, with the same indexes as Checks.
+	Reasons []string `json:"reasons"`
+}
+
+// ViolationSink receives a record of every policy violation found when admitting a pod or pod controller.
+// Records are emitted on the admission path, so implementations should not block.
+type ViolationSink interface {
+	RecordViolation(ctx context.Context, record ViolationRecord)
+}
+
+// recordViolation emits a record of the violation of the policy of the mode to the ViolationSink, if set.
+func (a *Admission) recordViolation(ctx context.Context, mode metrics.Mode, lv api.LevelVersion, result *policy.AggregateCheckResult, attrs api.Attributes) {
+	if a.ViolationSink == nil {
+		return
+	}
+	record := ViolationRecord{
+		Time:        time.Now(),
+		Mode:        mode,
+		Operation:   string(attrs.GetOperation()),
+		Resource:    attrs.GetResource().GroupResource().String(),
+		Subresource: attrs.GetSubresource(),
+		APIVersion:  attrs.GetKind().GroupVersion().String(),
+		Kind:        attrs.GetKind().Kind,
+		Namespace:   attrs.GetNamespace(),
+		Name:        attrs.GetName(),
+		User:        attrs.GetUserName(),
+		Level:       lv.Level,
+		Version:     lv.Version.String(),
+		DryRun:      api.IsDryRun(attrs),
+	}
+	if obj, err := attrs.GetObject(); err == nil && obj != nil {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			record.UID = accessor.GetUID()
+		}
+	}
+	for i, r := range result.ForbiddenResults {
+		record.Checks = append(record.Checks, r.CheckID)
+		record.Reasons = append(record.Reasons, result.ForbiddenReasons[i])
+	}
+	a.ViolationSink.RecordViolation(ctx, record)
+}
+
+// ViolationSinks is a ViolationSink emitting every record to each of its sinks.
+type ViolationSinks []ViolationSink
+
+func (s ViolationSinks) RecordViolation(ctx context.Context, record ViolationRecord) {
+	for _, sink := range s {
+		sink.RecordViolation(ctx, record)
+	}
+}
+
+// writerViolationSink writes every record to a writer as a line of JSON.
+type writerViolationSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterViolationSink returns a ViolationSink writing every record to w as a line of JSON,
+// such as os.Stdout. Writes are serialized.
+func NewWriterViolationSink(w io.Writer) ViolationSink {
+	return &writerViolationSink{encoder: json.NewEncoder(w)}
+}
+
+// NewFileViolationSink returns a ViolationSink appending every record to the file as a line of JSON,
+// creating the file if needed. The returned file must be closed by the caller once the sink is no longer used.
+func NewFileViolationSink(path string) (ViolationSink, io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewWriterViolationSink(f), f, nil
+}
+
+func (s *writerViolationSink) RecordViolation(ctx context.Context, record ViolationRecord) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.encoder.Encode(record); err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to write PodSecurity violation record")
+	}
+}
+
+// HTTPViolationSink posts every record as JSON to a collector. Records are queued and posted by Run,
+// so that admission does not wait for the collector. Records are dropped while the queue is full.
+type HTTPViolationSink struct {
+	url    string
+	client *http.Client
+	queue  chan ViolationRecord
+}
+
+var _ ViolationSink = &HTTPViolationSink{}
+
+// NewHTTPViolationSink returns a sink posting records to the URL with the client, queueing up to queueSize
+// records. Run must be called to post the queued records.
+func NewHTTPViolationSink(url string, client *http.Client, queueSize int) *HTTPViolationSink {
+	return &HTTPViolationSink{
+		url:    url,
+		client: client,
+		queue:  make(chan ViolationRecord, queueSize),
+	}
+}
+
+func (s *HTTPViolationSink) RecordViolation(ctx context.Context, record ViolationRecord) {
+	select {
+	case s.queue <- record:
+	default:
+		klog.FromContext(ctx).V(2).Info("Dropped PodSecurity violation record, the queue is full", "url", s.url)
+	}
+}
+
+// Run posts the queued records until the context is done.
+func (s *HTTPViolationSink) Run(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-s.queue:
+			if err := s.post(ctx, record); err != nil {
+				logger.Error(err, "Failed to post PodSecurity violation record", "url", s.url)
+			}
+		}
+	}
+}
+
+func (s *HTTPViolationSink) post(ctx context.Context, record ViolationRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2/ktesting"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/metrics"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/test"
+	"k8s.io/utils/ptr"
+)
+
+type testViolationSink struct {
+	lock    sync.Mutex
+	records []ViolationRecord
+}
+
+func (s *testViolationSink) RecordViolation(_ context.Context, record ViolationRecord) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	record.Time = time.Time{}
+	s.records = append(s.records, record)
+}
+
+func TestViolationSink(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err, "loading default config")
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	sink := &testViolationSink{}
+
+	a := &Admission{
+		PodLister:     &testPodLister{},
+		Evaluator:     evaluator,
+		Configuration: config,
+		Metrics:       &FakeRecorder{},
+		ViolationSink: sink,
+		NamespaceGetter: testNamespaceGetter{
+			"enforced": {ObjectMeta: metav1.ObjectMeta{
+				Name: "enforced",
+				Labels: map[string]string{
+					api.EnforceLevelLabel: string(api.LevelBaseline),
+					api.AuditLevelLabel:   string(api.LevelRestricted),
+					api.WarnLevelLabel:    string(api.LevelRestricted),
+				},
+			}},
+			"audited": {ObjectMeta: metav1.ObjectMeta{
+				Name: "audited",
+				Labels: map[string]string{
+					api.AuditLevelLabel:   string(api.LevelBaseline),
+					api.AuditVersionLabel: "v1.30",
+					api.WarnLevelLabel:    string(api.LevelBaseline),
+				},
+			}},
+		},
+	}
+	require.NoError(t, a.CompleteConfiguration(), "CompleteConfiguration()")
+	require.NoError(t, a.ValidateConfiguration(), "ValidateConfiguration()")
+
+	pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	pod.Name = "test-pod"
+	pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+	for _, namespace := range []string{"enforced", "audited"} {
+		a.Validate(ctx, &api.AttributesRecord{
+			Name:      pod.Name,
+			Namespace: namespace,
+			Kind:      schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Resource:  schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Operation: admissionv1.Create,
+			Object:    pod,
+			Username:  "alice",
+		})
+	}
+
+	record := func(mode metrics.Mode, namespace string, level api.Level, version string, checks []policy.CheckID, reasons []string) ViolationRecord {
+		return ViolationRecord{
+			Mode:       mode,
+			Operation:  "CREATE",
+			Resource:   "pods",
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  namespace,
+			Name:       "test-pod",
+			User:       "alice",
+			Level:      level,
+			Version:    version,
+			Checks:     checks,
+			Reasons:    reasons,
+		}
+	}
+	restrictedChecks := []policy.CheckID{"privileged", "allowPrivilegeEscalation", "capabilities_restricted", "runAsNonRoot", "seccompProfile_restricted"}
+	restrictedReasons := []string{"privileged", "allowPrivilegeEscalation != false", "unrestricted capabilities", "runAsNonRoot != true", "seccompProfile"}
+	// Warnings are not evaluated for denied requests.
+	assert.Equal(t, []ViolationRecord{
+		record(metrics.ModeEnforce, "enforced", api.LevelBaseline, "latest", []policy.CheckID{"privileged"}, []string{"privileged"}),
+		record(metrics.ModeAudit, "enforced", api.LevelRestricted, "latest", restrictedChecks, restrictedReasons),
+		record(metrics.ModeAudit, "audited", api.LevelBaseline, "v1.30", []policy.CheckID{"privileged"}, []string{"privileged"}),
+		record(metrics.ModeWarn, "audited", api.LevelBaseline, "latest", []policy.CheckID{"privileged"}, []string{"privileged"}),
+	}, sink.records)
+}
+
+func TestWriterViolationSink(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	var buf bytes.Buffer
+	sink := NewWriterViolationSink(&buf)
+	record := ViolationRecord{
+		Time:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Mode:       metrics.ModeAudit,
+		Operation:  "CREATE",
+		Resource:   "deployments.apps",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "ns",
+		Name:       "app",
+		User:       "alice",
+		Level:      api.LevelBaseline,
+		Version:    "latest",
+		Checks:     []policy.CheckID{"privileged"},
+		Reasons:    []string{"privileged"},
+	}
+	sink.RecordViolation(ctx, record)
+	sink.RecordViolation(ctx, record)
+
+	line := `{"time":"2026-01-02T03:04:05Z","mode":"audit","operation":"CREATE","resource":"deployments.apps","apiVersion":"apps/v1","kind":"Deployment","namespace":"ns","name":"app","user":"alice","level":"baseline","version":"latest","checks":["privileged"],"reasons":["privileged"]}`
+	assert.Equal(t, line+"\n"+line+"\n", buf.String())
+}
+
+func TestFileViolationSink(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	path := filepath.Join(t.TempDir(), "violations.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0644))
+
+	sink, closer, err := NewFileViolationSink(path)
+	require.NoError(t, err)
+	sink.RecordViolation(ctx, ViolationRecord{Mode: metrics.ModeWarn})
+	require.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2, "records are appended")
+	assert.Contains(t, lines[1], `"mode":"warn"`)
+}
+
+func TestHTTPViolationSink(t *testing.T) {
+	_, ctx := ktesting.NewTestContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan ViolationRecord, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var record ViolationRecord
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		received <- record
+	}))
+	defer server.Close()
+
+	sink := NewHTTPViolationSink(server.URL, server.Client(), 1)
+	sink.RecordViolation(ctx, ViolationRecord{Name: "first"})
+	// The queue is full until Run starts.
+	sink.RecordViolation(ctx, ViolationRecord{Name: "dropped"})
+	go sink.Run(ctx)
+
+	receive := func(name string) {
+		select {
+		case record := <-received:
+			assert.Equal(t, name, record.Name)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for record %q", name)
+		}
+	}
+	receive("first")
+	sink.RecordViolation(ctx, ViolationRecord{Name: "second"})
+	receive("second")
+}
@@ -47,6 +47,18 @@ type Attributes interface {
 	GetOldObject() (runtime.Object, error)
 	// GetUserName is the requesting user's authenticated name.
 	GetUserName() string
+	// GetUserGroups is the names of the groups the requesting user is a member of.
+	GetUserGroups() []string
+	// GetUserExtra is any additional information about the requesting user provided by the authenticator.
+	GetUserExtra() map[string][]string
+}
+
+// IsDryRun returns true if the request is a dry run, whose object is not persisted. Attributes report
+// dry runs by implementing an IsDryRun() bool method, which is not part of the Attributes interface
+// so that existing implementations keep satisfying it.
+func IsDryRun(attrs Attributes) bool {
+	d, ok := attrs.(interface{ IsDryRun() bool })
+	return ok && d.IsDryRun()
 }
 
 // AttributesRecord is a simple struct implementing the Attributes interface.
@@ -60,6 +72,9 @@ type AttributesRecord struct {
 	Object      runtime.Object
 	OldObject   runtime.Object
 	Username    string
+	Groups      []string
+	Extra       map[string][]string
+	DryRun      bool
 }
 
 func (a *AttributesRecord) GetName() string {
@@ -83,12 +98,21 @@ func (a *AttributesRecord) GetOperation() admissionv1.Operation {
 func (a *AttributesRecord) GetUserName() string {
 	return a.Username
 }
+func (a *AttributesRecord) GetUserGroups() []string {
+	return a.Groups
+}
+func (a *AttributesRecord) GetUserExtra() map[string][]string {
+	return a.Extra
+}
 func (a *AttributesRecord) GetObject() (runtime.Object, error) {
 	return a.Object, nil
 }
 func (a *AttributesRecord) GetOldObject() (runtime.Object, error) {
 	return a.OldObject, nil
 }
+func (a *AttributesRecord) IsDryRun() bool {
+	return a.DryRun
+}
 
 var _ Attributes = &AttributesRecord{}
 
@@ -128,12 +152,28 @@ func (a *attributes) GetOperation() admissionv1.Operation {
 func (a *attributes) GetUserName() string {
 	return a.r.UserInfo.Username
 }
+func (a *attributes) GetUserGroups() []string {
+	return a.r.UserInfo.Groups
+}
+func (a *attributes) GetUserExtra() map[string][]string {
+	if a.r.UserInfo.Extra == nil {
+		return nil
+	}
+	extra := make(map[string][]string, len(a.r.UserInfo.Extra))
+	for k, v := range a.r.UserInfo.Extra {
+		extra[k] = []string(v)
+	}
+	return extra
+}
 func (a *attributes) GetObject() (runtime.Object, error) {
 	return a.decode(a.r.Object)
 }
 func (a *attributes) GetOldObject() (runtime.Object, error) {
 	return a.decode(a.r.OldObject)
 }
+func (a *attributes) IsDryRun() bool {
+	return a.r.DryRun != nil && *a.r.DryRun
+}
 func (a *attributes) decode(in runtime.RawExtension) (runtime.Object, error) {
 	if in.Raw == nil {
 		return nil, nil
@@ -24,12 +24,6 @@ const (
 	LevelRestricted Level = "restricted"
 )
 
-var validLevels = []string{
-	string(LevelPrivileged),
-	string(LevelBaseline),
-	string(LevelRestricted),
-}
-
 const VersionLatest = "latest"
 
 const AuditAnnotationPrefix = labelPrefix
@@ -44,7 +38,9 @@ const (
 	WarnLevelLabel      = labelPrefix + "warn"
 	WarnVersionLabel    = labelPrefix + "warn-version"
 
-	ExemptionReasonAnnotationKey = "exempt"
-	AuditViolationsAnnotationKey = "audit-violations"
-	EnforcedPolicyAnnotationKey  = "enforce-policy"
+	ExemptionReasonAnnotationKey  = "exempt"
+	ExemptChecksAnnotationKey     = "exempt-checks"
+	RemediatedChecksAnnotationKey = "remediated-checks"
+	AuditViolationsAnnotationKey  = "audit-violations"
+	EnforcedPolicyAnnotationKey   = "enforce-policy"
 )
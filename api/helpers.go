@@ -96,16 +96,12 @@ func LatestVersion() Version {
 // ParseLevel returns the level that should be evaluated.
 // level must be "privileged", "baseline", or "restricted".
 // if level does not match one of those strings, "restricted" and an error is returned.
+// Custom levels are parsed with LevelSet.ParseLevel.
 func ParseLevel(level string) (Level, error) {
-	switch Level(level) {
-	case LevelPrivileged, LevelBaseline, LevelRestricted:
-		return Level(level), nil
-	default:
-		return LevelRestricted, fmt.Errorf(`must be one of %s`, strings.Join(validLevels, ", "))
-	}
+	return (*LevelSet)(nil).ParseLevel(level)
 }
 
-// Valid checks whether the level l is a valid level.
+// Valid checks whether the level l is a valid built-in level.
 func (l *Level) Valid() bool {
 	switch *l {
 	case LevelPrivileged, LevelBaseline, LevelRestricted:
@@ -178,84 +174,16 @@ func (p *Policy) FullyPrivileged() bool {
 // falling back to the provided defaults when a label is unspecified. A valid policy is always
 // returned, even when an error is returned. If labels cannot be parsed correctly, the values of
 // "restricted" and "latest" are used for level and version respectively.
+// Only the built-in levels are accepted; custom levels are resolved with LevelSet.PolicyToEvaluate.
 func PolicyToEvaluate(labels map[string]string, defaults Policy) (Policy, field.ErrorList) {
-	var (
-		err  error
-		errs field.ErrorList
-
-		p = defaults
-
-		hasEnforceLevel              bool
-		hasWarnLevel, hasWarnVersion bool
-	)
-	if len(labels) == 0 {
-		return p, nil
-	}
-	if level, ok := labels[EnforceLevelLabel]; ok {
-		p.Enforce.Level, err = ParseLevel(level)
-		hasEnforceLevel = (err == nil) // Don't default warn in case of error
-		errs = appendErr(errs, err, EnforceLevelLabel, level)
-	}
-	if version, ok := labels[EnforceVersionLabel]; ok {
-		p.Enforce.Version, err = ParseVersion(version)
-		errs = appendErr(errs, err, EnforceVersionLabel, version)
-	}
-	if level, ok := labels[AuditLevelLabel]; ok {
-		p.Audit.Level, err = ParseLevel(level)
-		errs = appendErr(errs, err, AuditLevelLabel, level)
-		if err != nil {
-			p.Audit.Level = LevelPrivileged // Fail open for audit.
-		}
-	}
-	if version, ok := labels[AuditVersionLabel]; ok {
-		p.Audit.Version, err = ParseVersion(version)
-		errs = appendErr(errs, err, AuditVersionLabel, version)
-	}
-	if level, ok := labels[WarnLevelLabel]; ok {
-		hasWarnLevel = true
-		p.Warn.Level, err = ParseLevel(level)
-		errs = appendErr(errs, err, WarnLevelLabel, level)
-		if err != nil {
-			p.Warn.Level = LevelPrivileged // Fail open for warn.
-		}
-	}
-	if version, ok := labels[WarnVersionLabel]; ok {
-		hasWarnVersion = true
-		p.Warn.Version, err = ParseVersion(version)
-		errs = appendErr(errs, err, WarnVersionLabel, version)
-	}
-
-	// Default warn to the enforce level when explicitly set to a more restrictive level.
-	if !hasWarnLevel && hasEnforceLevel && CompareLevels(p.Enforce.Level, p.Warn.Level) > 0 {
-		p.Warn.Level = p.Enforce.Level
-		if !hasWarnVersion {
-			p.Warn.Version = p.Enforce.Version
-		}
-	}
-
-	return p, errs
+	return (*LevelSet)(nil).PolicyToEvaluate(labels, defaults)
 }
 
-// CompareLevels returns an integer comparing two levels by strictness. The result will be 0 if
+// CompareLevels returns an integer comparing two built-in levels by strictness. The result will be 0 if
 // a==b, -1 if a is less strict than b, and +1 if a is more strict than b.
+// Custom levels are compared with LevelSet.CompareLevels.
 func CompareLevels(a, b Level) int {
-	if a == b {
-		return 0
-	}
-	switch a {
-	case LevelPrivileged:
-		return -1
-	case LevelRestricted:
-		return 1
-	default:
-		if b == LevelPrivileged {
-			return 1
-		} else if b == LevelRestricted {
-			return -1
-		}
-	}
-	// This should only happen if both a & b are invalid levels.
-	return 0
+	return (*LevelSet)(nil).CompareLevels(a, b)
 }
 
 var labelsPath = field.NewPath("metadata", "labels")
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// CustomLevel orders a level declared in configuration relative to the other levels.
+type CustomLevel struct {
+	// Name is the name of the level, used as the value of the level labels.
+	Name Level
+	// After is the level immediately less strict than this level.
+	// It must be a built-in level or a custom level preceding this level.
+	After Level
+}
+
+// LevelSet is an ordered set of valid levels: the built-in levels, and any custom levels declared in configuration.
+// A nil *LevelSet holds only the built-in levels. A LevelSet is immutable once created, so that different
+// configurations can be evaluated concurrently with their own levels.
+type LevelSet struct {
+	// order lists the valid levels, from least to most strict.
+	order []Level
+}
+
+var builtinLevelSet = &LevelSet{order: []Level{LevelPrivileged, LevelBaseline, LevelRestricted}}
+
+// NewLevelSet returns the set of the built-in levels and the given custom levels. Each custom level is ordered
+// as stricter than its After level, and less strict than any level that is stricter than its After level.
+// No two custom levels may share an After level.
+func NewLevelSet(levels []CustomLevel) (*LevelSet, error) {
+	order := builtinLevelSet.Levels()
+	afters := map[Level]Level{}
+	for _, l := range levels {
+		if l.Name == "" {
+			return nil, fmt.Errorf("custom level name must not be empty")
+		}
+		if indexOf(order, l.Name) >= 0 {
+			return nil, fmt.Errorf("custom level %s: already defined", l.Name)
+		}
+		i := indexOf(order, l.After)
+		if i < 0 {
+			return nil, fmt.Errorf("custom level %s: unknown level %q to order after", l.Name, l.After)
+		}
+		if other, ok := afters[l.After]; ok {
+			return nil, fmt.Errorf("custom level %s: levels %s and %s are both ordered after %s", l.Name, other, l.Name, l.After)
+		}
+		afters[l.After] = l.Name
+		order = append(order[:i+1], append([]Level{l.Name}, order[i+1:]...)...)
+	}
+	return &LevelSet{order: order}, nil
+}
+
+// Levels returns the valid levels, built-in and custom, from least to most strict.
+func (s *LevelSet) Levels() []Level {
+	if s == nil {
+		s = builtinLevelSet
+	}
+	return append([]Level(nil), s.order...)
+}
+
+// Valid checks whether the level is a valid level of the set.
+func (s *LevelSet) Valid(level Level) bool {
+	return s.index(level) >= 0
+}
+
+// ParseLevel returns the level that should be evaluated.
+// level must be one of the levels of the set.
+// if level does not match one of those strings, "restricted" and an error is returned.
+func (s *LevelSet) ParseLevel(level string) (Level, error) {
+	l := Level(level)
+	if s.Valid(l) {
+		return l, nil
+	}
+	var valid []string
+	for _, l := range s.Levels() {
+		valid = append(valid, string(l))
+	}
+	return LevelRestricted, fmt.Errorf(`must be one of %s`, strings.Join(valid, ", "))
+}
+
+// CompareLevels returns an integer comparing two levels by strictness. The result will be 0 if
+// a==b, -1 if a is less strict than b, and +1 if a is more strict than b.
+// Invalid levels are considered as strict as baseline.
+func (s *LevelSet) CompareLevels(a, b Level) int {
+	if a == b {
+		return 0
+	}
+	ia, ib := s.index(a), s.index(b)
+	if ia < 0 {
+		ia = s.index(LevelBaseline)
+	}
+	if ib < 0 {
+		ib = s.index(LevelBaseline)
+	}
+	switch {
+	case ia < ib:
+		return -1
+	case ia > ib:
+		return 1
+	default:
+		// This should only happen if either a or b is an invalid level.
+		return 0
+	}
+}
+
+// PolicyToEvaluate resolves the PodSecurity namespace labels to the policy for that namespace,
+// falling back to the provided defaults when a label is unspecified. A valid policy is always
+// returned, even when an error is returned. If labels cannot be parsed correctly, the values of
+// "restricted" and "latest" are used for level and version respectively.
+func (s *LevelSet) PolicyToEvaluate(labels map[string]string, defaults Policy) (Policy, field.ErrorList) {
+	var (
+		err  error
+		errs field.ErrorList
+
+		p = defaults
+
+		hasEnforceLevel              bool
+		hasWarnLevel, hasWarnVersion bool
+	)
+	if len(labels) == 0 {
+		return p, nil
+	}
+	if level, ok := labels[EnforceLevelLabel]; ok {
+		p.Enforce.Level, err = s.ParseLevel(level)
+		hasEnforceLevel = (err == nil) // Don't default warn in case of error
+		errs = appendErr(errs, err, EnforceLevelLabel, level)
+	}
+	if version, ok := labels[EnforceVersionLabel]; ok {
+		p.Enforce.Version, err = ParseVersion(version)
+		errs = appendErr(errs, err, EnforceVersionLabel, version)
+	}
+	if level, ok := labels[AuditLevelLabel]; ok {
+		p.Audit.Level, err = s.ParseLevel(level)
+		errs = appendErr(errs, err, AuditLevelLabel, level)
+		if err != nil {
+			p.Audit.Level = LevelPrivileged // Fail open for audit.
+		}
+	}
+	if version, ok := labels[AuditVersionLabel]; ok {
+		p.Audit.Version, err = ParseVersion(version)
+		errs = appendErr(errs, err, AuditVersionLabel, version)
+	}
+	if level, ok := labels[WarnLevelLabel]; ok {
+		hasWarnLevel = true
+		p.Warn.Level, err = s.ParseLevel(level)
+		errs = appendErr(errs, err, WarnLevelLabel, level)
+		if err != nil {
+			p.Warn.Level = LevelPrivileged // Fail open for warn.
+		}
+	}
+	if version, ok := labels[WarnVersionLabel]; ok {
+		hasWarnVersion = true
+		p.Warn.Version, err = ParseVersion(version)
+		errs = appendErr(errs, err, WarnVersionLabel, version)
+	}
+
+	// Default warn to the enforce level when explicitly set to a more restrictive level.
+	if !hasWarnLevel && hasEnforceLevel && s.CompareLevels(p.Enforce.Level, p.Warn.Level) > 0 {
+		p.Warn.Level = p.Enforce.Level
+		if !hasWarnVersion {
+			p.Warn.Version = p.Enforce.Version
+		}
+	}
+
+	return p, errs
+}
+
+// index returns the position of the level in the strictness order, or -1 if the level is invalid.
+func (s *LevelSet) index(level Level) int {
+	if s == nil {
+		s = builtinLevelSet
+	}
+	return indexOf(s.order, level)
+}
+
+func indexOf(levels []Level, level Level) int {
+	for i, l := range levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomLevels(t *testing.T) {
+	levels, err := NewLevelSet([]CustomLevel{
+		{Name: "restricted-plus", After: LevelRestricted},
+		{Name: "baseline-minus", After: LevelPrivileged},
+		{Name: "baseline-plus", After: LevelBaseline},
+		{Name: "restricted-plus-plus", After: "restricted-plus"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Level{LevelPrivileged, "baseline-minus", LevelBaseline, "baseline-plus", LevelRestricted, "restricted-plus", "restricted-plus-plus"}, levels.Levels())
+
+	level, err := levels.ParseLevel("baseline-minus")
+	require.NoError(t, err)
+	assert.Equal(t, Level("baseline-minus"), level)
+	_, err = levels.ParseLevel("unknown")
+	assert.EqualError(t, err, "must be one of privileged, baseline-minus, baseline, baseline-plus, restricted, restricted-plus, restricted-plus-plus")
+
+	assert.Equal(t, 1, levels.CompareLevels("restricted-plus", LevelRestricted))
+	assert.Equal(t, -1, levels.CompareLevels("restricted-plus", "restricted-plus-plus"))
+	assert.Equal(t, 1, levels.CompareLevels("baseline-minus", LevelPrivileged))
+	assert.Equal(t, -1, levels.CompareLevels("baseline-minus", LevelBaseline))
+	assert.Equal(t, -1, levels.CompareLevels("baseline-plus", LevelRestricted))
+	assert.Equal(t, 0, levels.CompareLevels("unknown", LevelBaseline))
+	assert.Equal(t, 1, levels.CompareLevels("unknown", "baseline-minus"))
+
+	defaults := Policy{
+		Enforce: LevelVersion{LevelPrivileged, LatestVersion()},
+		Audit:   LevelVersion{LevelPrivileged, LatestVersion()},
+		Warn:    LevelVersion{LevelRestricted, LatestVersion()},
+	}
+	labels := map[string]string{EnforceLevelLabel: "restricted-plus"}
+	policy, errs := levels.PolicyToEvaluate(labels, defaults)
+	assert.Empty(t, errs)
+	assert.Equal(t, Level("restricted-plus"), policy.Enforce.Level)
+	assert.Equal(t, Level("restricted-plus"), policy.Warn.Level, "warn defaults to the stricter enforce level")
+
+	// Custom levels are only valid in their own set.
+	other, err := NewLevelSet([]CustomLevel{{Name: "other", After: LevelBaseline}})
+	require.NoError(t, err)
+	assert.False(t, other.Valid("restricted-plus"))
+	assert.True(t, other.Valid("other"))
+	assert.False(t, levels.Valid("other"))
+	_, errs = PolicyToEvaluate(labels, defaults)
+	assert.NotEmpty(t, errs, "the package functions only accept built-in levels")
+	assert.Equal(t, []Level{LevelPrivileged, LevelBaseline, LevelRestricted}, (*LevelSet)(nil).Levels())
+	l := Level("restricted-plus")
+	assert.False(t, l.Valid())
+}
+
+func TestNewLevelSetInvalid(t *testing.T) {
+	testcases := []struct {
+		name        string
+		levels      []CustomLevel
+		expectedErr string
+	}{{
+		name:        "empty name",
+		levels:      []CustomLevel{{After: LevelBaseline}},
+		expectedErr: "custom level name must not be empty",
+	}, {
+		name:        "built-in name",
+		levels:      []CustomLevel{{Name: LevelBaseline, After: LevelPrivileged}},
+		expectedErr: "custom level baseline: already defined",
+	}, {
+		name:        "duplicate name",
+		levels:      []CustomLevel{{Name: "custom", After: LevelPrivileged}, {Name: "custom", After: LevelBaseline}},
+		expectedErr: "custom level custom: already defined",
+	}, {
+		name:        "unknown after",
+		levels:      []CustomLevel{{Name: "custom", After: "later"}, {Name: "later", After: LevelBaseline}},
+		expectedErr: `custom level custom: unknown level "later" to order after`,
+	}, {
+		name:        "shared after",
+		levels:      []CustomLevel{{Name: "a", After: LevelBaseline}, {Name: "b", After: LevelBaseline}},
+		expectedErr: "custom level b: levels a and b are both ordered after baseline",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewLevelSet(tc.levels)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
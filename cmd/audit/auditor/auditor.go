@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditor implements a command for evaluating the workloads of a cluster against the policies of their namespaces.
+package auditor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/pod-security-admission/admission"
+	admissionapi "k8s.io/pod-security-admission/admission/api"
+	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/cmd/audit/auditor/options"
+	"k8s.io/pod-security-admission/cmd/internal/kubeconfig"
+	"k8s.io/pod-security-admission/metrics"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Exit codes returned by Execute.
+const (
+	// ExitClean indicates all workloads are allowed by the enforce policy of their namespace.
+	ExitClean = 0
+	// ExitViolations indicates at least one workload in a non-exempt namespace violates its enforce policy.
+	ExitViolations = 1
+	// ExitError indicates invalid arguments, or a cluster that could not be audited.
+	ExitError = 2
+)
+
+// exitCodeError is returned by the command to exit with a specific code without printing an error.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+// NewAuditCommand creates a *cobra.Command object with default parameters.
+func NewAuditCommand() *cobra.Command {
+	opts := options.NewOptions()
+
+	cmdName := "podsecurity-audit"
+	if executable, err := os.Executable(); err == nil {
+		cmdName = filepath.Base(executable)
+	}
+	cmd := &cobra.Command{
+		Use: cmdName,
+		Long: `Evaluates every pod and pod controller in a cluster against the Pod Security policy of its namespace.
+
+The policy of each namespace is read from its labels, defaulting to the PodSecurity configuration,
+and the exemptions of the configuration are honored.
+
+Exits with 0 if all workloads are allowed by the enforce policy of their namespace, 1 if any workload
+in a non-exempt namespace violates it, and 2 if arguments are invalid or the cluster could not be audited.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runAudit(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+	}
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Execute runs the command and returns the exit code.
+func Execute(cmd *cobra.Command) int {
+	err := cmd.Execute()
+	if err == nil {
+		return ExitClean
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	return ExitError
+}
+
+// Config holds the loaded options.Options used to set up the auditor.
+type Config struct {
+	KubeConfig        *restclient.Config
+	PodSecurityConfig *admissionapi.PodSecurityConfiguration
+}
+
+// LoadConfig loads the Config from the Options.
+func LoadConfig(opts *options.Options) (*Config, error) {
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	var c Config
+
+	// Load Kube Client
+	var err error
+	c.KubeConfig, err = kubeconfig.Load(opts.Kubeconfig, opts.ClientQPSLimit, opts.ClientQPSBurst, "podsecurity-audit")
+	if err != nil {
+		return nil, err
+	}
+
+	// Load PodSecurity config
+	c.PodSecurityConfig, err = podsecurityconfigloader.LoadFromFile(opts.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func runAudit(ctx context.Context, stdout io.Writer, opts *options.Options) error {
+	c, err := LoadConfig(opts)
+	if err != nil {
+		return err
+	}
+	client, err := kubernetes.NewForConfig(c.KubeConfig)
+	if err != nil {
+		return err
+	}
+	auditor, err := NewAuditor(client, c.PodSecurityConfig)
+	if err != nil {
+		return err
+	}
+	report, err := auditor.Audit(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeReport(stdout, opts.Output, report); err != nil {
+		return err
+	}
+	if report.Violating() {
+		return &exitCodeError{code: ExitViolations}
+	}
+	return nil
+}
+
+// Auditor evaluates the workloads of every namespace against the policy of the namespace.
+type Auditor struct {
+	Client    kubernetes.Interface
+	Admission *admission.Admission
+}
+
+// NewAuditor creates an Auditor for the cluster of the client, using the default policy and
+// exemptions of the PodSecurity configuration.
+func NewAuditor(client kubernetes.Interface, config *admissionapi.PodSecurityConfiguration) (*Auditor, error) {
+	evaluator, err := policy.NewEvaluator(append(policy.DefaultChecks(), policy.CustomChecks()...), nil,
+		admission.EvaluatorOptions(config)...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create PodSecurityRegistry: %w", err)
+	}
+	a := &admission.Admission{
+		Configuration:       config,
+		Evaluator:           evaluator,
+		Metrics:             metrics.NewPrometheusRecorder(api.GetAPIVersion()),
+		PodSpecExtractor:    admission.DefaultPodSpecExtractor{},
+		PodLister:           admission.PodListerFromClient(client),
+		NamespaceGetter:     admission.NamespaceGetterFromClient(client),
+		PodControllerLister: admission.PodControllerListerFromClient(client),
+	}
+	if err := a.CompleteConfiguration(); err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	if err := a.ValidateConfiguration(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &Auditor{Client: client, Admission: a}, nil
+}
+
+// Report is the compliance report of a cluster.
+type Report struct {
+	// Namespaces holds the report of every namespace, ordered by name.
+	Namespaces []NamespaceReport `json:"namespaces"`
+	// Levels summarizes the namespaces by enforce level, from least to most restrictive.
+	Levels []LevelSummary `json:"levels"`
+}
+
+// Violating returns true if any workload in a non-exempt namespace violates the enforce policy of the namespace.
+func (r *Report) Violating() bool {
+	for i := range r.Namespaces {
+		if !r.Namespaces[i].Exempt && len(r.Namespaces[i].Violating()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceReport holds the evaluation of the workloads of a namespace against its policy.
+type NamespaceReport struct {
+	admission.DryRunReport
+	// PolicyErrors lists the invalid policy labels of the namespace. Invalid labels fall back as
+	// described by api.PolicyToEvaluate.
+	PolicyErrors []string `json:"policyErrors,omitempty"`
+}
+
+// LevelSummary counts the namespaces enforcing a level, their workloads, and the workloads violating each mode.
+// Exempt namespaces are only counted in ExemptNamespaces, so that the violations agree with Report.Violating.
+type LevelSummary struct {
+	Level             api.Level `json:"level"`
+	Namespaces        int       `json:"namespaces"`
+	ExemptNamespaces  int       `json:"exemptNamespaces"`
+	Workloads         int       `json:"workloads"`
+	EnforceViolations int       `json:"enforceViolations"`
+	AuditViolations   int       `json:"auditViolations"`
+	WarnViolations    int       `json:"warnViolations"`
+}
+
+// Audit evaluates every pod and pod controller in the cluster against the policy of its namespace.
+func (a *Auditor) Audit(ctx context.Context) (*Report, error) {
+	namespaces, err := a.Client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	report := &Report{}
+	summaries := map[api.Level]int{}
+	summaryFor := func(level api.Level) *LevelSummary {
+		i, ok := summaries[level]
+		if !ok {
+			i = len(report.Levels)
+			summaries[level] = i
+			report.Levels = append(report.Levels, LevelSummary{Level: level})
+		}
+		return &report.Levels[i]
+	}
+	for _, level := range a.Admission.Levels().Levels() {
+		summaryFor(level)
+	}
+
+	for _, ns := range namespaces.Items {
+		nsPolicy, policyErrs := a.Admission.PolicyToEvaluate(ns.Labels)
+		dryRun, err := a.Admission.DryRunNamespacePolicy(ctx, ns.Name, nsPolicy)
+		if apierrors.IsNotFound(err) {
+			// The namespace was deleted since it was listed.
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to audit namespace %s: %w", ns.Name, err)
+		}
+		nsReport := NamespaceReport{DryRunReport: *dryRun}
+		for _, policyErr := range policyErrs {
+			nsReport.PolicyErrors = append(nsReport.PolicyErrors, policyErr.Error())
+		}
+		report.Namespaces = append(report.Namespaces, nsReport)
+
+		summary := summaryFor(nsPolicy.Enforce.Level)
+		if dryRun.Exempt {
+			summary.ExemptNamespaces++
+			continue
+		}
+		summary.Namespaces++
+		summary.Workloads += len(dryRun.Workloads)
+		for _, w := range dryRun.Workloads {
+			if len(w.Enforce) > 0 {
+				summary.EnforceViolations++
+			}
+			if len(w.Audit) > 0 {
+				summary.AuditViolations++
+			}
+			if len(w.Warn) > 0 {
+				summary.WarnViolations++
+			}
+		}
+	}
+	return report, nil
+}
+
+func writeReport(w io.Writer, format string, report *Report) error {
+	if format == options.OutputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tENFORCE\tAUDIT\tWARN\tWORKLOADS\tENFORCE VIOLATIONS\tAUDIT VIOLATIONS\tWARN VIOLATIONS")
+	for _, ns := range report.Namespaces {
+		name := ns.Namespace
+		if ns.Exempt {
+			name += " (exempt)"
+		}
+		var enforce, audit, warn int
+		for _, w := range ns.Workloads {
+			if len(w.Enforce) > 0 {
+				enforce++
+			}
+			if len(w.Audit) > 0 {
+				audit++
+			}
+			if len(w.Warn) > 0 {
+				warn++
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\n", name, ns.Enforce, ns.Audit, ns.Warn, len(ns.Workloads), enforce, audit, warn)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "LEVEL\tNAMESPACES\tEXEMPT NAMESPACES\tWORKLOADS\tENFORCE VIOLATIONS\tAUDIT VIOLATIONS\tWARN VIOLATIONS")
+	for _, l := range report.Levels {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\n", l.Level, l.Namespaces, l.ExemptNamespaces, l.Workloads, l.EnforceViolations, l.AuditViolations, l.WarnViolations)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, ns := range report.Namespaces {
+		for _, policyErr := range ns.PolicyErrors {
+			fmt.Fprintf(w, "%s: invalid policy: %s\n", ns.Namespace, policyErr)
+		}
+		for _, wl := range ns.Workloads {
+			for _, v := range wl.Enforce {
+				fmt.Fprintf(w, "%s: %s/%s violates enforce %s: %s\n", ns.Namespace, wl.Kind, wl.Name, ns.Enforce, violationMessage(v))
+			}
+		}
+	}
+	return nil
+}
+
+func violationMessage(v admission.DryRunViolation) string {
+	if v.Detail == "" {
+		return v.Reason
+	}
+	return v.Reason + " (" + v.Detail + ")"
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditor
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/cmd/audit/auditor/options"
+)
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func pod(namespace, name string, hostNetwork bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			HostNetwork: hostNetwork,
+			Containers:  []corev1.Container{{Name: "c", Image: "registry.k8s.io/pause"}},
+		},
+	}
+}
+
+func TestAudit(t *testing.T) {
+	hostNetworkDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "host-network"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: pod("", "", true).Spec},
+		},
+	}
+	client := fake.NewSimpleClientset(
+		namespace("apps", map[string]string{api.EnforceLevelLabel: "baseline", api.WarnLevelLabel: "restricted"}),
+		namespace("kube-system", nil),
+		namespace("legacy", map[string]string{api.EnforceLevelLabel: "baseline"}),
+		namespace("typo", map[string]string{api.EnforceLevelLabel: "baseline", api.AuditLevelLabel: "strict"}),
+		pod("apps", "web", false),
+		hostNetworkDeployment,
+		pod("kube-system", "proxy", true),
+		pod("legacy", "old", true),
+	)
+	config, err := load.LoadFromData([]byte(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+exemptions:
+  namespaces: ["kube-system", "legacy"]
+`))
+	require.NoError(t, err)
+
+	auditor, err := NewAuditor(client, config)
+	require.NoError(t, err)
+	report, err := auditor.Audit(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, report.Namespaces, 4)
+	apps := report.Namespaces[0]
+	assert.Equal(t, "apps", apps.Namespace)
+	assert.Equal(t, "baseline:latest", apps.Enforce)
+	assert.Equal(t, "restricted:latest", apps.Warn)
+	require.Len(t, apps.Workloads, 2)
+	assert.Equal(t, "Deployment", apps.Workloads[0].Kind)
+	assert.NotEmpty(t, apps.Workloads[0].Enforce)
+	assert.Empty(t, apps.Workloads[1].Enforce)
+	assert.NotEmpty(t, apps.Workloads[1].Warn)
+
+	kubeSystem := report.Namespaces[1]
+	assert.True(t, kubeSystem.Exempt)
+	assert.Equal(t, "privileged:latest", kubeSystem.Enforce)
+
+	typo := report.Namespaces[3]
+	assert.Len(t, typo.PolicyErrors, 1)
+	assert.Equal(t, "privileged:latest", typo.Audit, "audit fails open")
+
+	assert.Equal(t, []LevelSummary{
+		{Level: api.LevelPrivileged, ExemptNamespaces: 1},
+		// The violations of the exempt legacy namespace are not counted, as they do not fail the audit.
+		{Level: api.LevelBaseline, Namespaces: 2, ExemptNamespaces: 1, Workloads: 2, EnforceViolations: 1, WarnViolations: 2},
+		{Level: api.LevelRestricted},
+	}, report.Levels)
+	assert.True(t, report.Violating())
+
+	var out bytes.Buffer
+	require.NoError(t, writeReport(&out, options.OutputText, report))
+	assert.Contains(t, out.String(), "kube-system (exempt)")
+	assert.Contains(t, out.String(), `apps: Deployment/host-network violates enforce baseline:latest: host namespaces (hostNetwork=true)`)
+	assert.Contains(t, out.String(), "typo: invalid policy: ")
+	assert.NotContains(t, out.String(), "kube-system: ")
+
+	out.Reset()
+	require.NoError(t, writeReport(&out, options.OutputJSON, report))
+	assert.True(t, strings.HasPrefix(out.String(), "{\n  \"namespaces\": [\n    {\n      \"namespace\": \"apps\","), out.String())
+}
+
+func TestAuditClean(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		namespace("default", map[string]string{api.EnforceLevelLabel: "baseline"}),
+		pod("default", "web", false),
+	)
+	config, err := load.LoadFromData(nil)
+	require.NoError(t, err)
+	auditor, err := NewAuditor(client, config)
+	require.NoError(t, err)
+	report, err := auditor.Audit(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.Violating())
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := options.NewOptions()
+	assert.Empty(t, opts.Validate())
+	opts.Output = "yaml"
+	assert.Len(t, opts.Validate(), 1)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	DefaultClientQPSLimit = 20
+	DefaultClientQPSBurst = 50
+)
+
+// Output formats of the audit report.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Options has all the params needed to audit a cluster.
+type Options struct {
+	// Kubeconfig is the file path to the KubeConfig file to use. Only for out-of-cluster configuration.
+	Kubeconfig string
+
+	// Config is the file path to the PodSecurity configuration file, which provides the default
+	// policy of unlabeled namespaces and the exemptions.
+	Config string
+
+	ClientQPSLimit float32
+	ClientQPSBurst int
+
+	// Output is the format of the report written to stdout.
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{
+		ClientQPSLimit: DefaultClientQPSLimit,
+		ClientQPSBurst: DefaultClientQPSBurst,
+		Output:         OutputText,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file specifying how to connect to the API server. Leave empty to use an in-cluster config.")
+	fs.StringVar(&o.Config, "config", o.Config, "The path to the PodSecurity configuration file. Leave empty to use the default configuration.")
+	fs.Float32Var(&o.ClientQPSLimit, "client-qps-limit", o.ClientQPSLimit, "Client QPS limit for throttling requests to the API server.")
+	fs.IntVar(&o.ClientQPSBurst, "client-qps-burst", o.ClientQPSBurst, "Client QPS burst limit for throttling requests to the API server.")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, "The report format. One of text, json.")
+}
+
+// Validate validates all the required options.
+func (o *Options) Validate() []error {
+	var errs []error
+
+	switch o.Output {
+	case OutputText, OutputJSON:
+	default:
+		errs = append(errs, fmt.Errorf("--output: unknown format %q, must be one of text, json", o.Output))
+	}
+
+	return errs
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeconfig loads the client configuration shared by the commands.
+package kubeconfig
+
+import (
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Load loads the client configuration from the kubeconfig file, or from the in-cluster configuration if the
+// file is empty, with the QPS limits and user agent of the command.
+func Load(kubeconfig string, qps float32, burst int, userAgent string) (*restclient.Config, error) {
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfig.QPS = qps
+	kubeConfig.Burst = burst
+	return restclient.AddUserAgent(kubeConfig, userAgent), nil
+}
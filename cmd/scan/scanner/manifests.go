@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// stdinSource is the source name used for manifests read from stdin.
+const stdinSource = "-"
+
+// manifestExtensions are the file extensions considered when walking a directory.
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// Manifest is a single object decoded from a manifest document.
+type Manifest struct {
+	// Source is the file the object was read from, or "-" for stdin.
+	Source string
+	// Document is the zero-based index of the document within the source.
+	Document int
+	// Object is the decoded object.
+	Object runtime.Object
+}
+
+// ParseError is returned for a manifest document that could not be decoded.
+type ParseError struct {
+	Source   string
+	Document int
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s[%d]: %v", e.Source, e.Document, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// expandPaths resolves the given paths to the list of manifest sources to read.
+// Directories are walked recursively, and only files with a known manifest extension are included.
+// Files named explicitly are always included. No paths, or a path of "-", reads from stdin.
+func expandPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return []string{stdinSource}, nil
+	}
+	var sources []string
+	for _, path := range paths {
+		if path == stdinSource {
+			sources = append(sources, stdinSource)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			sources = append(sources, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && manifestExtensions[strings.ToLower(filepath.Ext(p))] {
+				sources = append(sources, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sources, nil
+}
+
+// readSource decodes all objects from the named source.
+// A source that cannot be opened is returned as an error; documents that cannot be decoded are
+// returned as ParseErrors alongside the successfully decoded manifests.
+func readSource(source string, stdin io.Reader) ([]Manifest, []error, error) {
+	if source == stdinSource {
+		manifests, parseErrs := readManifests(source, stdin)
+		return manifests, parseErrs, nil
+	}
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	manifests, parseErrs := readManifests(source, f)
+	return manifests, parseErrs, nil
+}
+
+// readManifests decodes every YAML or JSON document in the reader.
+// Documents of kinds unknown to the scanner are skipped, since they cannot contain a pod spec.
+// List objects are flattened into their items.
+func readManifests(source string, r io.Reader) ([]Manifest, []error) {
+	var (
+		manifests []Manifest
+		errs      []error
+	)
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	for document := 0; ; document++ {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, &ParseError{Source: source, Document: document, Err: err})
+			break
+		}
+		objs, err := decodeDocument(data)
+		if err != nil {
+			errs = append(errs, &ParseError{Source: source, Document: document, Err: err})
+			continue
+		}
+		for _, obj := range objs {
+			manifests = append(manifests, Manifest{Source: source, Document: document, Object: obj})
+		}
+	}
+	return manifests, errs
+}
+
+// decodeDocument decodes a single YAML or JSON document into zero or more objects.
+func decodeDocument(data []byte) ([]runtime.Object, error) {
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(jsonData); len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		// empty or comment-only document
+		return nil, nil
+	}
+	obj, gvk, err := codecs.UniversalDeserializer().Decode(jsonData, nil, nil)
+	if runtime.IsNotRegisteredError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(*gvk)
+	list, ok := obj.(*corev1.List)
+	if !ok {
+		return []runtime.Object{obj}, nil
+	}
+	var objs []runtime.Object
+	for i, item := range list.Items {
+		itemObjs, err := decodeDocument(item.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+		objs = append(objs, itemObjs...)
+	}
+	return objs, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/report"
+)
+
+// Options has all the params needed to scan manifests.
+type Options struct {
+	// Level is the policy level to evaluate pod specs against.
+	Level string
+	// Version is the policy version to evaluate pod specs against.
+	Version string
+	// Output is the format of the report written to stdout.
+	Output string
+	// Explain traces the evaluation of every pod spec in the report.
+	Explain bool
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Level:   string(api.LevelRestricted),
+		Version: api.VersionLatest,
+		Output:  string(report.FormatText),
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Level, "level", o.Level, "The Pod Security Standards level to evaluate against. One of privileged, baseline, restricted.")
+	fs.StringVar(&o.Version, "version", o.Version, `The Pod Security Standards version to evaluate against. Either "latest" or "v1.x".`)
+	fs.StringVarP(&o.Output, "output", "o", o.Output, "The report format. One of text, json, sarif, junit. The text format only lists violations.")
+	fs.BoolVar(&o.Explain, "explain", o.Explain, "Trace the evaluation of every pod spec: the evaluated checks, the selected version of each check, overrides and timing. Only supported by the text and json formats.")
+}
+
+// Validate validates all the required options.
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if _, err := api.ParseLevel(o.Level); err != nil {
+		errs = append(errs, fmt.Errorf("--level: %w", err))
+	}
+	if _, err := api.ParseVersion(o.Version); err != nil {
+		errs = append(errs, fmt.Errorf("--version: %w", err))
+	}
+	if format, err := report.ParseFormat(o.Output); err != nil {
+		errs = append(errs, fmt.Errorf("--output: %w", err))
+	} else if o.Explain && format != report.FormatText && format != report.FormatJSON {
+		errs = append(errs, fmt.Errorf("--explain: not supported by the %s format", format))
+	}
+
+	return errs
+}
+
+// LevelVersion returns the parsed level and version. Options must be valid.
+func (o *Options) LevelVersion() api.LevelVersion {
+	level, _ := api.ParseLevel(o.Level)
+	version, _ := api.ParseVersion(o.Version)
+	return api.LevelVersion{Level: level, Version: version}
+}
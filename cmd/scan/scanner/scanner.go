@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scanner implements a command for evaluating pod specs in manifests offline.
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/pod-security-admission/admission"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/cmd/scan/scanner/options"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/report"
+)
+
+// Exit codes returned by Execute.
+const (
+	// ExitClean indicates all scanned pod specs are allowed.
+	ExitClean = 0
+	// ExitViolations indicates at least one scanned pod spec violates the policy.
+	ExitViolations = 1
+	// ExitError indicates invalid arguments, or manifests that could not be read or parsed.
+	ExitError = 2
+)
+
+// exitCodeError is returned by the command to exit with a specific code without printing an error.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+// NewScanCommand creates a *cobra.Command object with default parameters.
+func NewScanCommand() *cobra.Command {
+	opts := options.NewOptions()
+
+	cmdName := "podsecurity-scan"
+	if executable, err := os.Executable(); err == nil {
+		cmdName = filepath.Base(executable)
+	}
+	cmd := &cobra.Command{
+		Use: cmdName + " [path...]",
+		Long: `Evaluates the pod specs of manifests against the Pod Security Standards without a cluster.
+
+Manifests are read from the given files and directories, or from stdin if no paths or "-" is given.
+Directories are searched recursively for .yaml, .yml and .json files.
+
+Exits with 0 if all pod specs are allowed, 1 if any pod spec violates the policy,
+and 2 if arguments are invalid or any manifest could not be read or parsed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return runScan(cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), opts, args)
+		},
+		SilenceErrors: true,
+	}
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Execute runs the command and returns the exit code.
+func Execute(cmd *cobra.Command) int {
+	err := cmd.Execute()
+	if err == nil {
+		return ExitClean
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	return ExitError
+}
+
+func runScan(stdin io.Reader, stdout, stderr io.Writer, opts *options.Options, paths []string) error {
+	if errs := opts.Validate(); len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	sources, err := expandPaths(paths)
+	if err != nil {
+		return err
+	}
+	evaluator, err := policy.NewEvaluator(append(policy.DefaultChecks(), policy.CustomChecks()...), nil)
+	if err != nil {
+		return fmt.Errorf("could not create PodSecurityRegistry: %w", err)
+	}
+	s := &Scanner{
+		Evaluator:        evaluator,
+		PodSpecExtractor: admission.DefaultPodSpecExtractor{},
+		LevelVersion:     opts.LevelVersion(),
+		Explain:          opts.Explain,
+	}
+
+	var (
+		manifests []Manifest
+		errs      []error
+	)
+	for _, source := range sources {
+		sourceManifests, parseErrs, err := readSource(source, stdin)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, sourceManifests...)
+		errs = append(errs, parseErrs...)
+	}
+	results, evalErrs := s.Scan(manifests)
+	errs = append(errs, evalErrs...)
+
+	for _, err := range errs {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+	}
+	format, _ := report.ParseFormat(opts.Output)
+	if err := report.Write(stdout, format, reportEntries(s.LevelVersion, results)); err != nil {
+		return err
+	}
+
+	switch {
+	case len(errs) > 0:
+		return &exitCodeError{code: ExitError}
+	case !allAllowed(results):
+		return &exitCodeError{code: ExitViolations}
+	default:
+		return nil
+	}
+}
+
+// Scanner evaluates the pod specs embedded in manifests against a single policy level and version.
+type Scanner struct {
+	Evaluator        policy.Evaluator
+	PodSpecExtractor admission.PodSpecExtractor
+	LevelVersion     api.LevelVersion
+	// Explain records the trace of each evaluation in the results, if the Evaluator is a policy.Explainer.
+	Explain bool
+}
+
+// Result is the evaluation result of the pod spec embedded in a manifest.
+type Result struct {
+	Manifest
+
+	Kind      schema.GroupVersionKind
+	Namespace string
+	Name      string
+
+	policy.AggregateCheckResult
+
+	// Explanation is the trace of the evaluation, if the Scanner explains evaluations.
+	Explanation *policy.Explanation
+}
+
+// Scan evaluates every manifest with an extractable pod spec. Manifests of kinds without a pod spec are skipped.
+// Results are returned in the order of the manifests, and include allowed pod specs.
+func (s *Scanner) Scan(manifests []Manifest) ([]Result, []error) {
+	var (
+		results []Result
+		errs    []error
+	)
+	for _, m := range manifests {
+		gvk := m.Object.GetObjectKind().GroupVersionKind()
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		if !s.PodSpecExtractor.HasPodSpec(gvr.GroupResource()) {
+			continue
+		}
+		podMetadata, podSpec, err := s.PodSpecExtractor.ExtractPodSpec(m.Object)
+		if err != nil {
+			errs = append(errs, &ParseError{Source: m.Source, Document: m.Document, Err: err})
+			continue
+		}
+		if podMetadata == nil && podSpec == nil {
+			// if a controller with an optional pod spec does not contain a pod spec, skip validation
+			continue
+		}
+		result := Result{Manifest: m, Kind: gvk}
+		if accessor, err := meta.Accessor(m.Object); err == nil {
+			result.Namespace = accessor.GetNamespace()
+			result.Name = accessor.GetName()
+		}
+		if explainer, ok := s.Evaluator.(policy.Explainer); ok && s.Explain {
+			explanation := explainer.ExplainPod(s.LevelVersion, podMetadata, podSpec, policy.WithFieldErrors())
+			result.Explanation = &explanation
+			result.AggregateCheckResult = policy.AggregateCheckResults(explanation.Results())
+		} else {
+			result.AggregateCheckResult = policy.AggregateCheckResults(s.Evaluator.EvaluatePod(s.LevelVersion, podMetadata, podSpec, policy.WithFieldErrors()))
+		}
+		results = append(results, result)
+	}
+	return results, errs
+}
+
+func allAllowed(results []Result) bool {
+	for _, r := range results {
+		if !r.Allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// reportEntries converts the scan results to report entries.
+func reportEntries(lv api.LevelVersion, results []Result) []report.Entry {
+	entries := make([]report.Entry, 0, len(results))
+	for _, r := range results {
+		entries = append(entries, report.Entry{
+			Object: report.Object{
+				Source:     r.Source,
+				Document:   r.Document,
+				APIVersion: r.Kind.GroupVersion().String(),
+				Kind:       r.Kind.Kind,
+				Namespace:  r.Namespace,
+				Name:       r.Name,
+			},
+			Policy:      lv,
+			Result:      r.AggregateCheckResult,
+			Explanation: r.Explanation,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	baselinePodManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: baseline
+  namespace: default
+spec:
+  containers:
+  - name: c
+    image: registry.k8s.io/pause
+`
+	privilegedDeploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: privileged
+spec:
+  selector:
+    matchLabels:
+      app: privileged
+  template:
+    metadata:
+      labels:
+        app: privileged
+    spec:
+      hostNetwork: true
+      containers:
+      - name: c
+        image: registry.k8s.io/pause
+`
+	serviceManifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+`
+	customResourceManifest = `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`
+)
+
+func TestScan(t *testing.T) {
+	testcases := []struct {
+		name           string
+		args           []string
+		files          map[string]string
+		stdin          string
+		expectCode     int
+		expectStdout   []string
+		expectStderr   string
+		expectNoStdout bool
+	}{
+		{
+			name:           "clean stdin",
+			args:           []string{"--level=baseline"},
+			stdin:          baselinePodManifest + "---\n" + serviceManifest + "---\n" + customResourceManifest,
+			expectCode:     ExitClean,
+			expectNoStdout: true,
+		},
+		{
+			name:         "violations in directory",
+			args:         []string{"--level=baseline", "manifests"},
+			files:        map[string]string{"manifests/pod.yaml": baselinePodManifest, "manifests/nested/deploy.yml": privilegedDeploymentManifest, "manifests/README.md": "not a manifest"},
+			expectCode:   ExitViolations,
+			expectStdout: []string{`manifests/nested/deploy.yml[0]: Deployment privileged: violates PodSecurity "baseline:latest": host namespaces (hostNetwork=true)`},
+		},
+		{
+			name:         "restricted violations",
+			args:         []string{"--level=restricted", "--version=v1.23", "pod.yaml"},
+			files:        map[string]string{"pod.yaml": baselinePodManifest},
+			expectCode:   ExitViolations,
+			expectStdout: []string{`pod.yaml[0]: Pod default/baseline: violates PodSecurity "restricted:v1.23": allowPrivilegeEscalation != false`},
+		},
+		{
+			name:         "junit output",
+			args:         []string{"--level=baseline", "--output=junit", "pod.yaml", "deploy.yaml"},
+			files:        map[string]string{"pod.yaml": baselinePodManifest, "deploy.yaml": privilegedDeploymentManifest},
+			expectCode:   ExitViolations,
+			expectStdout: []string{`<testsuites name="pod-security" tests="2" failures="1">`, `<testcase classname="baseline:latest" name="Pod default/baseline"></testcase>`},
+		},
+		{
+			name:       "explain",
+			args:       []string{"--level=restricted", "--version=v1.23", "--explain", "pod.yaml"},
+			files:      map[string]string{"pod.yaml": baselinePodManifest},
+			expectCode: ExitViolations,
+			expectStdout: []string{
+				`pod.yaml[0]: Pod default/baseline: violates PodSecurity "restricted:v1.23": allowPrivilegeEscalation != false`,
+				`  evaluated "restricted:v1.23" in `,
+				`  pass hostNamespaces (baseline, v1.0) in `,
+				`  pass restrictedVolumes (restricted, v1.0, overrides hostPathVolumes) in `,
+				`  fail capabilities_restricted (restricted, v1.22, overrides capabilities_baseline) in `,
+			},
+		},
+		{
+			name:           "explain unsupported output",
+			args:           []string{"--explain", "--output=sarif"},
+			expectCode:     ExitError,
+			expectNoStdout: true,
+			expectStderr:   "Error: --explain: not supported by the sarif format",
+		},
+		{
+			name:           "invalid output",
+			args:           []string{"--output=yaml"},
+			expectCode:     ExitError,
+			expectNoStdout: true,
+			expectStderr:   "Error: --output: must be one of",
+		},
+		{
+			name:         "list",
+			args:         []string{"--level=baseline"},
+			stdin:        `{"apiVersion":"v1","kind":"List","items":[` + `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a"},"spec":{"hostPID":true,"containers":[{"name":"c","image":"i"}]}}` + `]}`,
+			expectCode:   ExitViolations,
+			expectStdout: []string{`-[0]: Pod a: violates PodSecurity "baseline:latest": host namespaces (hostPID=true)`},
+		},
+		{
+			name:         "parse error",
+			args:         []string{"--level=baseline", "bad.yaml", "deploy.yaml"},
+			files:        map[string]string{"bad.yaml": "kind: [", "deploy.yaml": privilegedDeploymentManifest},
+			expectCode:   ExitError,
+			expectStdout: []string{`deploy.yaml[0]: Deployment privileged`},
+			expectStderr: "error: bad.yaml[0]:",
+		},
+		{
+			name:           "missing file",
+			args:           []string{"missing.yaml"},
+			expectCode:     ExitError,
+			expectNoStdout: true,
+			expectStderr:   "Error: stat missing.yaml",
+		},
+		{
+			name:           "invalid level",
+			args:           []string{"--level=unknown"},
+			expectCode:     ExitError,
+			expectNoStdout: true,
+			expectStderr:   "Error: --level: must be one of",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+			t.Chdir(dir)
+
+			var stdout, stderr bytes.Buffer
+			cmd := NewScanCommand()
+			cmd.SetArgs(tc.args)
+			cmd.SetIn(strings.NewReader(tc.stdin))
+			cmd.SetOut(&stdout)
+			cmd.SetErr(&stderr)
+
+			assert.Equal(t, tc.expectCode, Execute(cmd), "exit code")
+			if tc.expectNoStdout {
+				assert.Empty(t, stdout.String())
+			}
+			for _, expected := range tc.expectStdout {
+				assert.Contains(t, stdout.String(), expected)
+			}
+			if tc.expectStderr != "" {
+				assert.Contains(t, stderr.String(), tc.expectStderr)
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	admissionapi "k8s.io/pod-security-admission/admission/api"
+	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
+	admissionapiv1 "k8s.io/pod-security-admission/admission/api/v1"
+)
+
+// configResource is the cluster-scoped custom resource holding the fields of a PodSecurityConfiguration with
+// object metadata, as defined by webhook/crd/podsecurityconfigurations.yaml.
+var configResource = schema.GroupVersionResource{Group: "podsecurity.x-k8s.io", Version: "v1alpha1", Resource: "podsecurityconfigurations"}
+
+// setupConfigFileSource activates the configuration loaded from a file, which is watched by Start if set.
+func (s *Server) setupConfigFileSource(file string, data []byte, config *admissionapi.PodSecurityConfiguration) error {
+	s.configFile = file
+	return s.activateConfig(config, data)
+}
+
+// setupConfigMapSource activates the configuration held in the key of a ConfigMap, and reloads it through the
+// informer factory whenever the ConfigMap changes.
+func (s *Server) setupConfigMapSource(client clientset.Interface, namespace, name, key string) error {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the PodSecurity configuration ConfigMap: %w", err)
+	}
+	data, err := configMapData(configMap, key)
+	if err != nil {
+		return err
+	}
+	if err := s.activateConfigData(data); err != nil {
+		return err
+	}
+
+	informer := s.informerFactory.InformerFor(&corev1.ConfigMap{}, func(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return coreinformers.NewFilteredConfigMapInformer(client, namespace, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		})
+	})
+	logger := klog.Background().WithValues("configMap", klog.KRef(namespace, name), "key", key)
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				s.applyConfigMap(logger, configMap, key)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				s.applyConfigMap(logger, configMap, key)
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			logger.Info("PodSecurity configuration ConfigMap was deleted, keeping the current configuration")
+		},
+	})
+	return err
+}
+
+func (s *Server) applyConfigMap(logger klog.Logger, configMap *corev1.ConfigMap, key string) {
+	data, err := configMapData(configMap, key)
+	if err != nil {
+		logger.Error(err, "Failed to read the PodSecurity configuration, keeping the current configuration")
+		s.metrics.RecordConfigReload(false)
+		return
+	}
+	s.applyConfig(logger, data)
+}
+
+// configMapData returns the configuration held in the key of the ConfigMap.
+func configMapData(configMap *corev1.ConfigMap, key string) ([]byte, error) {
+	if data, ok := configMap.Data[key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[key]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("ConfigMap %s/%s has no %q key", configMap.Namespace, configMap.Name, key)
+}
+
+// setupConfigResourceSource activates the configuration held in a PodSecurityConfiguration custom resource,
+// and reloads it through a dynamic informer factory whenever the resource changes.
+func (s *Server) setupConfigResourceSource(client dynamic.Interface, name string) error {
+	resource, err := client.Resource(configResource).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the PodSecurity configuration resource: %w", err)
+	}
+	data, err := configResourceData(resource)
+	if err != nil {
+		return err
+	}
+	if err := s.activateConfigData(data); err != nil {
+		return err
+	}
+
+	s.dynamicInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0 /* no resync */, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	})
+	logger := klog.Background().WithValues("podSecurityConfiguration", klog.KRef("", name))
+	_, err = s.dynamicInformerFactory.ForResource(configResource).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if resource, ok := obj.(*unstructured.Unstructured); ok {
+				s.applyConfigResource(logger, resource)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if resource, ok := obj.(*unstructured.Unstructured); ok {
+				s.applyConfigResource(logger, resource)
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			logger.Info("PodSecurity configuration resource was deleted, keeping the current configuration")
+		},
+	})
+	return err
+}
+
+func (s *Server) applyConfigResource(logger klog.Logger, resource *unstructured.Unstructured) {
+	data, err := configResourceData(resource)
+	if err != nil {
+		logger.Error(err, "Failed to read the PodSecurity configuration, keeping the current configuration")
+		s.metrics.RecordConfigReload(false)
+		return
+	}
+	s.applyConfig(logger, data)
+}
+
+// configResourceData returns the configuration held in a PodSecurityConfiguration custom resource, as a
+// v1 configuration without the object metadata that the configuration API does not accept.
+func configResourceData(resource *unstructured.Unstructured) ([]byte, error) {
+	content := runtime.DeepCopyJSON(resource.Object)
+	delete(content, "metadata")
+	delete(content, "status")
+	content["apiVersion"] = admissionapiv1.SchemeGroupVersion.String()
+	content["kind"] = "PodSecurityConfiguration"
+	return json.Marshal(content)
+}
+
+// activateConfigData activates the initial configuration read from the API server.
+func (s *Server) activateConfigData(data []byte) error {
+	config, err := podsecurityconfigloader.LoadFromData(data)
+	if err != nil {
+		return err
+	}
+	return s.activateConfig(config, data)
+}
+
+// activateConfig activates the initial configuration, loaded from data.
+func (s *Server) activateConfig(config *admissionapi.PodSecurityConfiguration, data []byte) error {
+	delegate, err := s.newDelegate(config)
+	if err != nil {
+		return err
+	}
+	s.delegate.Store(delegate)
+	s.configData = data
+	return nil
+}
+
+// dynamicInformerSync is a readyz check that passes once the started informers of the dynamic informer factory,
+// which watches the configuration resource, have synced.
+type dynamicInformerSync struct {
+	factory dynamicinformer.DynamicSharedInformerFactory
+}
+
+func (d dynamicInformerSync) Name() string {
+	return "dynamic-informer-sync"
+}
+
+func (d dynamicInformerSync) Check(_ *http.Request) error {
+	stopCh := make(chan struct{})
+	// Close stopCh to check whether the informers are synced now.
+	close(stopCh)
+	var notSynced []string
+	for resource, synced := range d.factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			notSynced = append(notSynced, resource.String())
+		}
+	}
+	if len(notSynced) > 0 {
+		return fmt.Errorf("%d dynamic informers not synced yet: %v", len(notSynced), notSynced)
+	}
+	return nil
+}
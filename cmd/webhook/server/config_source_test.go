@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiserver "k8s.io/apiserver/pkg/server"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfigMapSource(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pod-security-webhook", Name: "config"},
+		Data:       map[string]string{"config.yaml": configWithEnforce("baseline")},
+	}
+	client := fake.NewSimpleClientset(configMap)
+	s, err := setup(&Config{
+		InsecureServing:    &apiserver.DeprecatedInsecureServingInfo{},
+		ConfigMapNamespace: configMap.Namespace,
+		ConfigMapName:      configMap.Name,
+		ConfigMapKey:       "config.yaml",
+	}, client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+	require.NoError(t, err)
+	assert.Equal(t, "baseline", enforceLevel(s))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.informerFactory.Start(ctx.Done())
+	s.informerFactory.WaitForCacheSync(ctx.Done())
+
+	update := func(level string) {
+		configMap.Data["config.yaml"] = configWithEnforce(level)
+		_, err := client.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+	update("restricted")
+	assert.Eventually(t, func() bool { return enforceLevel(s) == "restricted" }, 10*time.Second, 10*time.Millisecond)
+
+	// Invalid and deleted configurations are not loaded.
+	update("bogus")
+	require.NoError(t, client.CoreV1().ConfigMaps(configMap.Namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "restricted", enforceLevel(s))
+}
+
+func TestConfigMapSource_Missing(t *testing.T) {
+	_, err := setup(&Config{
+		InsecureServing:    &apiserver.DeprecatedInsecureServingInfo{},
+		ConfigMapNamespace: "pod-security-webhook",
+		ConfigMapName:      "config",
+		ConfigMapKey:       "config.yaml",
+	}, fake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+	assert.Error(t, err)
+
+	_, err = setup(&Config{
+		InsecureServing:    &apiserver.DeprecatedInsecureServingInfo{},
+		ConfigMapNamespace: "pod-security-webhook",
+		ConfigMapName:      "config",
+		ConfigMapKey:       "config.yaml",
+	}, fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pod-security-webhook", Name: "config"},
+	}), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+	assert.ErrorContains(t, err, `has no "config.yaml" key`)
+}
+
+func TestConfigResourceSource(t *testing.T) {
+	resource := configResourceWithEnforce(t, "baseline")
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configResource: "PodSecurityConfigurationList"}, resource)
+	s, err := setup(&Config{
+		InsecureServing:    &apiserver.DeprecatedInsecureServingInfo{},
+		ConfigResourceName: "cluster",
+	}, fake.NewSimpleClientset(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "baseline", enforceLevel(s))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.dynamicInformerFactory.Start(ctx.Done())
+	s.dynamicInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// Readiness covers the informer of the configuration resource.
+	recorder := httptest.NewRecorder()
+	s.newMux(false).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz/dynamic-informer-sync", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	_, err = client.Resource(configResource).Update(ctx, configResourceWithEnforce(t, "restricted"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return enforceLevel(s) == "restricted" }, 10*time.Second, 10*time.Millisecond)
+}
+
+func configResourceWithEnforce(t *testing.T, level string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(configWithEnforce(level)), &resource.Object))
+	resource.SetAPIVersion(configResource.GroupVersion().String())
+	resource.SetName("cluster")
+	return resource
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// dryRunAuthTimeout bounds the TokenReviews made to authenticate /dryrun requests.
+	dryRunAuthTimeout = 10 * time.Second
+	// dryRunAuthCacheTTL is how long the TokenReview and SubjectAccessReview decisions are cached.
+	dryRunAuthCacheTTL = 10 * time.Second
+)
+
+// setupDryRunAuth delegates the authentication and authorization of /dryrun requests to the API server.
+func (s *Server) setupDryRunAuth(client clientset.Interface) error {
+	authn, _, err := authenticatorfactory.DelegatingAuthenticatorConfig{
+		TokenAccessReviewClient:  client.AuthenticationV1(),
+		TokenAccessReviewTimeout: dryRunAuthTimeout,
+		WebhookRetryBackoff:      apiserveroptions.DefaultAuthWebhookRetryBackoff(),
+		CacheTTL:                 dryRunAuthCacheTTL,
+	}.New()
+	if err != nil {
+		return fmt.Errorf("could not create authenticator: %w", err)
+	}
+	authz, err := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: client.AuthorizationV1(),
+		AllowCacheTTL:             dryRunAuthCacheTTL,
+		DenyCacheTTL:              dryRunAuthCacheTTL,
+		WebhookRetryBackoff:       apiserveroptions.DefaultAuthWebhookRetryBackoff(),
+	}.New()
+	if err != nil {
+		return fmt.Errorf("could not create authorizer: %w", err)
+	}
+	s.dryRunAuthenticator = authn
+	s.dryRunAuthorizer = authz
+	return nil
+}
+
+// authorizeDryRun only lets through the /dryrun requests of users allowed to list the pods of the namespace,
+// since the report lists the workloads of the namespace and their pod templates with the webhook's permissions.
+func (s *Server) authorizeDryRun(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := klog.FromContext(r.Context())
+
+		resp, ok, err := s.dryRunAuthenticator.AuthenticateRequest(r)
+		if err != nil {
+			logger.V(2).Info("Unable to authenticate the dry run request", "error", err)
+		}
+		if err != nil || !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			http.Error(w, "the namespace query parameter is required", http.StatusBadRequest)
+			return
+		}
+		attrs := authorizer.AttributesRecord{
+			User:            resp.User,
+			Verb:            "list",
+			Namespace:       namespace,
+			APIVersion:      "v1",
+			Resource:        "pods",
+			ResourceRequest: true,
+		}
+		decision, reason, err := s.dryRunAuthorizer.Authorize(r.Context(), attrs)
+		if err != nil {
+			logger.Error(err, "Unable to authorize the dry run request", "user", resp.User.GetName(), "namespace", namespace)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if decision != authorizer.DecisionAllow {
+			http.Error(w, fmt.Sprintf("user %q cannot list pods in the namespace %q: %s", resp.User.GetName(), namespace, reason), http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	compbasemetrics "k8s.io/component-base/metrics"
+)
+
+func TestAuthorizeDryRun(t *testing.T) {
+	s := &Server{
+		dryRunAuthenticator: authenticator.RequestFunc(func(r *http.Request) (*authenticator.Response, bool, error) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				return nil, false, nil
+			}
+			return &authenticator.Response{User: &user.DefaultInfo{Name: "alice"}}, true, nil
+		}),
+		dryRunAuthorizer: authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+			if a.GetUser().GetName() == "alice" && a.GetVerb() == "list" && a.GetResource() == "pods" && a.GetNamespace() == "allowed" {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionNoOpinion, "no rule", nil
+		}),
+	}
+	handler := s.authorizeDryRun(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testcases := []struct {
+		name         string
+		token        string
+		namespace    string
+		expectedCode int
+	}{
+		{name: "unauthenticated", namespace: "allowed", expectedCode: http.StatusUnauthorized},
+		{name: "forbidden", token: "token", namespace: "other", expectedCode: http.StatusForbidden},
+		{name: "missing namespace", token: "token", expectedCode: http.StatusBadRequest},
+		{name: "allowed", token: "token", namespace: "allowed", expectedCode: http.StatusOK},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/dryrun?namespace="+tc.namespace, nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestNewMux_DryRunSecureOnly(t *testing.T) {
+	s := &Server{
+		informerFactory: kubeinformers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0),
+		metricsRegistry: compbasemetrics.NewKubeRegistry(),
+	}
+	r := httptest.NewRequest(http.MethodGet, "/dryrun?namespace=test", nil)
+	_, pattern := s.newMux(true).Handler(r)
+	assert.Equal(t, "/dryrun", pattern)
+	_, pattern = s.newMux(false).Handler(r)
+	assert.Equal(t, "/", pattern, "the insecure port must not serve dry runs")
+}
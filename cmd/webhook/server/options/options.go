@@ -17,6 +17,10 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/pflag"
 
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
@@ -27,6 +31,8 @@ const (
 	DefaultInsecurePort   = 8080
 	DefaultClientQPSLimit = 20
 	DefaultClientQPSBurst = 50
+
+	DefaultConfigMapKey = "podsecurityconfiguration.yaml"
 )
 
 // Options has all the params needed to run a PodSecurity webhook.
@@ -36,10 +42,29 @@ type Options struct {
 
 	// Config is the file path to the PodSecurity configuration file.
 	Config string
+	// ConfigMap is the namespace/name of a ConfigMap to read the PodSecurity configuration from, instead of a file.
+	ConfigMap string
+	// ConfigMapKey is the key of the ConfigMap that holds the PodSecurity configuration.
+	ConfigMapKey string
+	// ConfigResource is the name of a cluster-scoped PodSecurityConfiguration custom resource to read the
+	// PodSecurity configuration from, instead of a file.
+	ConfigResource string
 
 	ClientQPSLimit float32
 	ClientQPSBurst int
 
+	// EvaluationCacheSize is the number of evaluation results to cache. Caching is disabled if zero.
+	EvaluationCacheSize int
+
+	// ViolationSink is where a record of every policy violation is emitted: "stdout", an http:// or https://
+	// URL to post records to, or the path of a file to append records to. Records are not emitted if empty.
+	ViolationSink string
+	// PolicyReportInterval is how often PolicyReports are written for every namespace. PolicyReports are not
+	// written if zero.
+	PolicyReportInterval time.Duration
+	// EmitEvents records Events against pod controllers violating their audit or warn policy.
+	EmitEvents bool
+
 	SecureServing apiserveroptions.SecureServingOptions
 }
 
@@ -50,6 +75,7 @@ func NewOptions() *Options {
 		SecureServing:  *secureServing,
 		ClientQPSLimit: DefaultClientQPSLimit,
 		ClientQPSBurst: DefaultClientQPSBurst,
+		ConfigMapKey:   DefaultConfigMapKey,
 	}
 	o.SecureServing.BindPort = DefaultPort
 	return o
@@ -58,8 +84,15 @@ func NewOptions() *Options {
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file specifying how to connect to the API server. Leave empty to use an in-cluster config.")
 	fs.StringVar(&o.Config, "config", o.Config, "The path to the PodSecurity configuration file.")
+	fs.StringVar(&o.ConfigMap, "config-configmap", o.ConfigMap, "The namespace/name of a ConfigMap to read the PodSecurity configuration from, instead of --config.")
+	fs.StringVar(&o.ConfigMapKey, "config-configmap-key", o.ConfigMapKey, "The key of the --config-configmap ConfigMap that holds the PodSecurity configuration.")
+	fs.StringVar(&o.ConfigResource, "config-resource", o.ConfigResource, "The name of a cluster-scoped PodSecurityConfiguration custom resource to read the PodSecurity configuration from, instead of --config.")
 	fs.Float32Var(&o.ClientQPSLimit, "client-qps-limit", o.ClientQPSLimit, "Client QPS limit for throttling requests to the API server.")
 	fs.IntVar(&o.ClientQPSBurst, "client-qps-burst", o.ClientQPSBurst, "Client QPS burst limit for throttling requests to the API server.")
+	fs.StringVar(&o.ViolationSink, "violation-sink", o.ViolationSink, "Where to emit a JSON record of every enforce, audit and warn violation: \"stdout\", an http:// or https:// URL to post records to, or the path of a file to append records to.")
+	fs.BoolVar(&o.EmitEvents, "emit-events", o.EmitEvents, "Record Warning Events against pod controllers that violate the audit or warn policy of their namespace. Repeated Events are deduplicated and rate-limited.")
+	fs.DurationVar(&o.PolicyReportInterval, "policy-report-interval", o.PolicyReportInterval, "How often to evaluate the pods of every namespace against its policy, and write the failing checks to a wgpolicyk8s.io PolicyReport in the namespace. Set to 0 to disable PolicyReports.")
+	fs.IntVar(&o.EvaluationCacheSize, "evaluation-cache-size", o.EvaluationCacheSize, "The number of policy evaluation results to cache, keyed by the level, version, labels, annotations and spec of the pod. Set to 0 to disable caching.")
 
 	o.SecureServing.AddFlags(fs)
 }
@@ -69,6 +102,38 @@ func (o *Options) Validate() []error {
 	var errs []error
 
 	errs = append(errs, o.SecureServing.Validate()...)
+	sources := 0
+	for _, source := range []string{o.Config, o.ConfigMap, o.ConfigResource} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		errs = append(errs, fmt.Errorf("only one of --config, --config-configmap and --config-resource may be set"))
+	}
+	if o.ConfigMap != "" {
+		if _, _, err := o.ConfigMapNamespaceName(); err != nil {
+			errs = append(errs, err)
+		}
+		if o.ConfigMapKey == "" {
+			errs = append(errs, fmt.Errorf("--config-configmap-key must not be empty"))
+		}
+	}
+	if o.PolicyReportInterval < 0 {
+		errs = append(errs, fmt.Errorf("--policy-report-interval must not be negative, got %v", o.PolicyReportInterval))
+	}
+	if o.EvaluationCacheSize < 0 {
+		errs = append(errs, fmt.Errorf("--evaluation-cache-size must not be negative, got %d", o.EvaluationCacheSize))
+	}
 
 	return errs
 }
+
+// ConfigMapNamespaceName splits the --config-configmap option into the namespace and name of the ConfigMap.
+func (o *Options) ConfigMapNamespaceName() (namespace, name string, err error) {
+	namespace, name, ok := strings.Cut(o.ConfigMap, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("--config-configmap must be of the form namespace/name, got %q", o.ConfigMap)
+	}
+	return namespace, name, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/pod-security-admission/admission"
+)
+
+const (
+	// policyReportName is the name of the PolicyReport written to every namespace.
+	policyReportName = "pod-security"
+	// policyReportSource is the source of the results of the PolicyReports.
+	policyReportSource = "pod-security-admission"
+	// policyReportNamespaceTimeout bounds the evaluation of the pods of a namespace.
+	policyReportNamespaceTimeout = 30 * time.Second
+)
+
+// policyReportResource is the wgpolicyk8s.io PolicyReport resource.
+var policyReportResource = schema.GroupVersionResource{Group: "wgpolicyk8s.io", Version: "v1alpha2", Resource: "policyreports"}
+
+// policyReport is a wgpolicyk8s.io/v1alpha2 PolicyReport.
+type policyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Summary policyReportSummary  `json:"summary"`
+	Results []policyReportResult `json:"results,omitempty"`
+}
+
+type policyReportSummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+type policyReportResult struct {
+	Policy     string                   `json:"policy"`
+	Rule       string                   `json:"rule,omitempty"`
+	Category   string                   `json:"category,omitempty"`
+	Result     string                   `json:"result"`
+	Scored     bool                     `json:"scored"`
+	Source     string                   `json:"source"`
+	Message    string                   `json:"message,omitempty"`
+	Timestamp  metav1.Timestamp         `json:"timestamp"`
+	Resources  []corev1.ObjectReference `json:"resources,omitempty"`
+	Properties map[string]string        `json:"properties,omitempty"`
+}
+
+// policyReportController periodically evaluates the pods of every namespace against the policy of the
+// namespace, and writes the failing checks to a PolicyReport in the namespace.
+type policyReportController struct {
+	client     dynamic.Interface
+	namespaces corev1listers.NamespaceLister
+	// synced reports whether the namespace and pod informers have synced.
+	synced []cache.InformerSynced
+	// delegate returns the active Admission, so that reloaded configurations are used.
+	delegate func() *admission.Admission
+	interval time.Duration
+	now      func() time.Time
+}
+
+// Run writes the PolicyReports every interval until the context is done.
+func (c *policyReportController) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, c.sync, c.interval)
+}
+
+func (c *policyReportController) sync(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	namespaces, err := c.namespaces.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "Failed to list namespaces for PolicyReports")
+		return
+	}
+	for _, ns := range namespaces {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := c.syncNamespace(ctx, ns.Name); err != nil {
+			logger.Error(err, "Failed to write PolicyReport", "namespace", ns.Name)
+		}
+	}
+}
+
+// syncNamespace writes the PolicyReport of a namespace, or deletes it if the namespace is exempt.
+func (c *policyReportController) syncNamespace(ctx context.Context, namespace string) error {
+	evalCtx, cancel := context.WithTimeout(ctx, policyReportNamespaceTimeout)
+	defer cancel()
+	report, err := c.delegate().EvaluateNamespacePods(evalCtx, namespace)
+	if err != nil {
+		return err
+	}
+
+	reports := c.client.Resource(policyReportResource).Namespace(namespace)
+	if report.Exempt {
+		if err := reports.Delete(ctx, policyReportName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newPolicyReport(report, c.now()))
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	existing, err := reports.Get(ctx, policyReportName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = reports.Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	_, err = reports.Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// newPolicyReport converts the evaluation of the pods of a namespace to a PolicyReport, with a result for every
+// check failed by a pod at each distinct level and version of the policy. Failures of the enforce policy are
+// reported as fail, and failures of only the audit or warn policy as warn. Pods allowed by a level and version
+// are only counted as passing in the summary, to keep the size of the report proportional to the violations.
+// Pods left unevaluated because of the evaluation timeout or the maximum number of pods are counted as skipped.
+func newPolicyReport(report *admission.DryRunReport, now time.Time) *policyReport {
+	pr := &policyReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyReportResource.GroupVersion().String(),
+			Kind:       "PolicyReport",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyReportName,
+			Namespace: report.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "pod-security-webhook"},
+		},
+	}
+	timestamp := metav1.Timestamp{Seconds: now.Unix()}
+	// Pods that were not evaluated are counted as skipped for each distinct level and version of the policy,
+	// so that a truncated evaluation is not mistaken for a complete one.
+	pr.Summary.Skip = report.Skipped * sets.New(report.Enforce, report.Audit, report.Warn).Len()
+	for _, w := range report.Workloads {
+		// Group the modes by level and version, so that each failed check is reported once per policy.
+		var policies []string
+		modes := map[string][]string{}
+		violations := map[string][]admission.DryRunViolation{}
+		for _, m := range []struct {
+			mode, policy string
+			violations   []admission.DryRunViolation
+		}{
+			{"enforce", report.Enforce, w.Enforce},
+			{"audit", report.Audit, w.Audit},
+			{"warn", report.Warn, w.Warn},
+		} {
+			if _, ok := modes[m.policy]; !ok {
+				policies = append(policies, m.policy)
+				violations[m.policy] = m.violations
+			}
+			modes[m.policy] = append(modes[m.policy], m.mode)
+		}
+
+		for _, p := range policies {
+			if len(violations[p]) == 0 {
+				pr.Summary.Pass++
+				continue
+			}
+			result := "warn"
+			if modes[p][0] == "enforce" {
+				result = "fail"
+			}
+			for _, v := range violations[p] {
+				message := v.Reason
+				if v.Detail != "" {
+					message = fmt.Sprintf("%s (%s)", v.Reason, v.Detail)
+				}
+				pr.Results = append(pr.Results, policyReportResult{
+					Policy:    p,
+					Rule:      v.Check,
+					Category:  "Pod Security Standards",
+					Result:    result,
+					Scored:    true,
+					Source:    policyReportSource,
+					Message:   message,
+					Timestamp: timestamp,
+					Resources: []corev1.ObjectReference{{
+						APIVersion: w.APIVersion,
+						Kind:       w.Kind,
+						Namespace:  report.Namespace,
+						Name:       w.Name,
+					}},
+					Properties: map[string]string{"modes": strings.Join(modes[p], ",")},
+				})
+				if result == "fail" {
+					pr.Summary.Fail++
+				} else {
+					pr.Summary.Warn++
+				}
+			}
+		}
+	}
+	return pr
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/pod-security-admission/admission"
+	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/metrics"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/pod-security-admission/test"
+	"k8s.io/utils/ptr"
+)
+
+func TestPolicyReportController(t *testing.T) {
+	ctx := context.Background()
+
+	pod, err := test.GetMinimalValidPod(api.LevelBaseline, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	pod.Name = "privileged"
+	pod.Namespace = "test-ns"
+	pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+	exemptPod := pod.DeepCopy()
+	exemptPod.Namespace = "exempt-ns"
+	restrictedPod, err := test.GetMinimalValidPod(api.LevelRestricted, api.MajorMinorVersion(1, 23))
+	require.NoError(t, err)
+	restrictedPod.Name = "restricted"
+	restrictedPod.Namespace = "test-ns"
+
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "test-ns",
+			Labels: map[string]string{
+				api.EnforceLevelLabel: string(api.LevelBaseline),
+				api.AuditLevelLabel:   string(api.LevelBaseline),
+				api.WarnLevelLabel:    string(api.LevelRestricted),
+			},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "exempt-ns"}},
+	}
+	client := fake.NewSimpleClientset(namespaces[0], namespaces[1], pod, exemptPod, restrictedPod)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range namespaces {
+		require.NoError(t, indexer.Add(ns))
+	}
+	namespaceLister := corev1listers.NewNamespaceLister(indexer)
+
+	config, err := podsecurityconfigloader.LoadFromData(nil)
+	require.NoError(t, err)
+	config.Exemptions.Namespaces = []string{"exempt-ns"}
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
+	require.NoError(t, err)
+	a := &admission.Admission{
+		Configuration:   config,
+		Evaluator:       evaluator,
+		Metrics:         metrics.NewPrometheusRecorder(api.GetAPIVersion()),
+		NamespaceGetter: admission.NamespaceGetterFromListerAndClient(namespaceLister, client),
+		PodLister:       admission.PodListerFromClient(client),
+	}
+	require.NoError(t, a.CompleteConfiguration())
+	require.NoError(t, a.ValidateConfiguration())
+
+	staleReport := &unstructured.Unstructured{}
+	staleReport.SetAPIVersion("wgpolicyk8s.io/v1alpha2")
+	staleReport.SetKind("PolicyReport")
+	staleReport.SetNamespace("exempt-ns")
+	staleReport.SetName(policyReportName)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{policyReportResource: "PolicyReportList"}, staleReport)
+
+	c := &policyReportController{
+		client:     dynamicClient,
+		namespaces: namespaceLister,
+		delegate:   func() *admission.Admission { return a },
+		interval:   time.Minute,
+		now:        func() time.Time { return time.Unix(1000, 0) },
+	}
+	// Reports are created, and then updated.
+	c.sync(ctx)
+	c.sync(ctx)
+
+	reports := dynamicClient.Resource(policyReportResource)
+	_, err = reports.Namespace("exempt-ns").Get(ctx, policyReportName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "reports of exempt namespaces are deleted")
+
+	obj, err := reports.Namespace("test-ns").Get(ctx, policyReportName, metav1.GetOptions{})
+	require.NoError(t, err)
+	var report policyReport
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &report))
+	assert.Equal(t, "PolicyReport", report.Kind)
+	assert.Equal(t, policyReportSummary{Pass: 2, Fail: 1, Warn: 5}, report.Summary)
+	require.Len(t, report.Results, 6, "passing pods are only counted in the summary")
+
+	resources := []corev1.ObjectReference{{APIVersion: "v1", Kind: "Pod", Namespace: "test-ns", Name: "privileged"}}
+	assert.Equal(t, policyReportResult{
+		Policy:     "baseline:latest",
+		Rule:       "privileged",
+		Category:   "Pod Security Standards",
+		Result:     "fail",
+		Scored:     true,
+		Source:     policyReportSource,
+		Message:    `privileged (container "container1" must not set securityContext.privileged=true)`,
+		Timestamp:  metav1.Timestamp{Seconds: 1000},
+		Resources:  resources,
+		Properties: map[string]string{"modes": "enforce,audit"},
+	}, report.Results[0])
+	var warnRules []string
+	for _, r := range report.Results[1:] {
+		assert.Equal(t, "restricted:latest", r.Policy)
+		assert.Equal(t, "warn", r.Result)
+		assert.Equal(t, map[string]string{"modes": "warn"}, r.Properties)
+		assert.Equal(t, resources, r.Resources)
+		warnRules = append(warnRules, r.Rule)
+	}
+	assert.Equal(t, []string{"privileged", "allowPrivilegeEscalation", "capabilities_restricted", "runAsNonRoot", "seccompProfile_restricted"}, warnRules)
+}
+
+func TestNewPolicyReport_Skipped(t *testing.T) {
+	report := newPolicyReport(&admission.DryRunReport{
+		Namespace: "test-ns",
+		Enforce:   "baseline:latest",
+		Audit:     "baseline:latest",
+		Warn:      "restricted:latest",
+		Skipped:   3,
+	}, time.Unix(1000, 0))
+	assert.Equal(t, policyReportSummary{Skip: 6}, report.Summary, "skipped pods are counted for each distinct policy")
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"k8s.io/klog/v2"
+	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
+)
+
+// configResyncPeriod is how often the configuration file is checked for changes that were not notified.
+const configResyncPeriod = time.Minute
+
+// watchConfig reloads the configuration whenever the configuration file changes, until the context is done.
+// The directory of the file is watched rather than the file itself, so that the symlink swaps used to update
+// ConfigMap volumes are noticed. The file is also checked every configResyncPeriod, in case events are missed.
+func (s *Server) watchConfig(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+	if watcher, err := newConfigWatcher(s.configFile); err != nil {
+		logger.Error(err, "Failed to watch the PodSecurity configuration file, falling back to polling", "file", s.configFile)
+	} else {
+		defer watcher.Close()
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	ticker := time.NewTicker(configResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+			} else {
+				logger.Error(err, "Error watching the PodSecurity configuration file", "file", s.configFile)
+			}
+			continue
+		case <-ticker.C:
+		}
+		s.reloadConfig(ctx)
+	}
+}
+
+func newConfigWatcher(file string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating fsnotify watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error watching %s: %w", filepath.Dir(file), err)
+	}
+	return watcher, nil
+}
+
+// reloadConfig loads the configuration file if its content changed, and swaps the active Admission for one
+// using the new configuration. The active configuration is kept if the new one cannot be loaded or is invalid.
+func (s *Server) reloadConfig(ctx context.Context) {
+	logger := klog.FromContext(ctx).WithValues("file", s.configFile)
+
+	data, err := os.ReadFile(s.configFile)
+	if err != nil {
+		logger.Error(err, "Failed to read the PodSecurity configuration file, keeping the current configuration")
+		s.metrics.RecordConfigReload(false)
+		return
+	}
+	s.applyConfig(logger, data)
+}
+
+// applyConfig swaps the active Admission for one using the configuration in data, if it changed.
+// The active configuration is kept if the new one cannot be loaded or is invalid.
+func (s *Server) applyConfig(logger klog.Logger, data []byte) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+
+	if bytes.Equal(data, s.configData) {
+		return
+	}
+	// Only attempt to load each content once, whether it is valid or not.
+	s.configData = data
+
+	if err := s.swapConfig(data); err != nil {
+		logger.Error(err, "Failed to reload the PodSecurity configuration, keeping the current configuration")
+		s.metrics.RecordConfigReload(false)
+		return
+	}
+	logger.Info("Reloaded the PodSecurity configuration")
+	s.metrics.RecordConfigReload(true)
+}
+
+// swapConfig makes an Admission using the configuration in data the active one. The configuration, including
+// its custom levels, is owned by the new Admission, so requests in flight on the previous one are unaffected.
+func (s *Server) swapConfig(data []byte) error {
+	config, err := podsecurityconfigloader.LoadFromData(data)
+	if err != nil {
+		return err
+	}
+	delegate, err := s.newDelegate(config)
+	if err != nil {
+		return err
+	}
+	s.delegate.Store(delegate)
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiserver "k8s.io/apiserver/pkg/server"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/component-base/metrics/testutil"
+	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
+	"k8s.io/pod-security-admission/api"
+)
+
+func configWithEnforce(level string) string {
+	return fmt.Sprintf(`
+apiVersion: pod-security.admission.config.k8s.io/v1
+kind: PodSecurityConfiguration
+defaults:
+  enforce: %s
+`, level)
+}
+
+func setupWithConfigFile(t *testing.T, file string) *Server {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	config, err := podsecurityconfigloader.LoadFromData(data)
+	require.NoError(t, err)
+	s, err := Setup(&Config{
+		InsecureServing:       &apiserver.DeprecatedInsecureServingInfo{},
+		KubeConfig:            &restclient.Config{},
+		PodSecurityConfig:     config,
+		PodSecurityConfigFile: file,
+		podSecurityConfigData: data,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func enforceLevel(s *Server) string {
+	return s.delegate.Load().Configuration.Defaults.Enforce
+}
+
+func TestReloadConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("baseline")), 0644))
+	s := setupWithConfigFile(t, file)
+	ctx := context.Background()
+
+	// Unchanged content is not reloaded.
+	s.reloadConfig(ctx)
+	assert.Equal(t, "baseline", enforceLevel(s))
+
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("restricted")), 0644))
+	s.reloadConfig(ctx)
+	assert.Equal(t, "restricted", enforceLevel(s))
+
+	// Invalid configurations are not loaded, and are only attempted once.
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("bogus")), 0644))
+	s.reloadConfig(ctx)
+	s.reloadConfig(ctx)
+	assert.Equal(t, "restricted", enforceLevel(s))
+
+	// Custom levels of a configuration found invalid are not accepted.
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("restricted")+`
+levels:
+- name: restricted-plus
+  base: restricted
+exemptions:
+  checks:
+  - check: unknown
+`), 0644))
+	s.reloadConfig(ctx)
+	assert.Equal(t, "restricted", enforceLevel(s))
+	_, errs := s.delegate.Load().PolicyToEvaluate(map[string]string{api.EnforceLevelLabel: "restricted-plus"})
+	assert.NotEmpty(t, errs)
+
+	require.NoError(t, os.Remove(file))
+	s.reloadConfig(ctx)
+	assert.Equal(t, "restricted", enforceLevel(s))
+
+	expected := `
+	# HELP pod_security_config_automatic_reloads_total [ALPHA] Number of automatic reloads of the PodSecurity configuration, by status.
+	# TYPE pod_security_config_automatic_reloads_total counter
+	pod_security_config_automatic_reloads_total{status="failure"} 3
+	pod_security_config_automatic_reloads_total{status="success"} 1
+	`
+	assert.NoError(t, testutil.GatherAndCompare(s.metricsRegistry, strings.NewReader(expected), "pod_security_config_automatic_reloads_total"))
+}
+
+func TestReloadConfig_CustomLevels(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("restricted-plus")+`
+levels:
+- name: restricted-plus
+  base: restricted
+`), 0644))
+	s := setupWithConfigFile(t, file)
+	labels := map[string]string{api.EnforceLevelLabel: "restricted-plus"}
+
+	// Requests in flight on the previous Admission keep evaluating its levels after a reload.
+	previous := s.delegate.Load()
+	require.NoError(t, os.WriteFile(file, []byte(configWithEnforce("restricted")), 0644))
+	s.reloadConfig(context.Background())
+	assert.Equal(t, "restricted", enforceLevel(s))
+	_, errs := s.delegate.Load().PolicyToEvaluate(labels)
+	assert.NotEmpty(t, errs)
+	policy, errs := previous.PolicyToEvaluate(labels)
+	assert.Empty(t, errs)
+	assert.Equal(t, api.Level("restricted-plus"), policy.Enforce.Level)
+}
+
+func TestWatchConfig_SymlinkSwap(t *testing.T) {
+	// ConfigMap volumes are updated by atomically swapping the ..data symlink to a new directory.
+	dir := t.TempDir()
+	writeVersion := func(version, level string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, version), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "config.yaml"), []byte(configWithEnforce(level)), 0644))
+		require.NoError(t, os.Symlink(version, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	writeVersion("..v1", "baseline")
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), file))
+	s := setupWithConfigFile(t, file)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.watchConfig(ctx)
+
+	// Give the watch time to start.
+	time.Sleep(100 * time.Millisecond)
+	writeVersion("..v2", "restricted")
+	assert.Eventually(t, func() bool { return enforceLevel(s) == "restricted" }, 10*time.Second, 10*time.Millisecond)
+}
@@ -27,19 +27,31 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	apiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	compbasemetrics "k8s.io/component-base/metrics"
 	"k8s.io/component-base/version/verflag"
 	"k8s.io/klog/v2"
@@ -47,6 +59,7 @@ import (
 	admissionapi "k8s.io/pod-security-admission/admission/api"
 	podsecurityconfigloader "k8s.io/pod-security-admission/admission/api/load"
 	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/cmd/internal/kubeconfig"
 	"k8s.io/pod-security-admission/cmd/webhook/server/options"
 	"k8s.io/pod-security-admission/metrics"
 	"k8s.io/pod-security-admission/policy"
@@ -54,6 +67,9 @@ import (
 
 const maxRequestSize = int64(3 * 1024 * 1024)
 
+// defaultDryRunTimeout bounds the dry runs of requests that do not set a timeout.
+const defaultDryRunTimeout = 30 * time.Second
+
 // NewSchedulerCommand creates a *cobra.Command object with default parameters and registryOptions
 func NewServerCommand() *cobra.Command {
 	opts := options.NewOptions()
@@ -105,28 +121,72 @@ type Server struct {
 
 	informerFactory kubeinformers.SharedInformerFactory
 
-	delegate *admission.Admission
-
+	// delegate is the active *admission.Admission, which is swapped when the configuration is reloaded.
+	delegate atomic.Pointer[admission.Admission]
+	// newDelegate creates an Admission for a configuration.
+	newDelegate func(*admissionapi.PodSecurityConfiguration) (*admission.Admission, error)
+	// configFile is the path of the configuration file, which is watched for changes if set.
+	configFile string
+	// configLock serializes the reloads of the configuration.
+	configLock sync.Mutex
+	// configData is the content of the configuration that was last loaded.
+	configData []byte
+
+	// dynamicInformerFactory watches the PodSecurityConfiguration custom resource, if it is the configuration source.
+	dynamicInformerFactory dynamicinformer.DynamicSharedInformerFactory
+
+	// violationSink receives a record of every policy violation, if set.
+	violationSink admission.ViolationSink
+	// httpViolationSink is the violationSink if it posts records, which is run by Start.
+	httpViolationSink *admission.HTTPViolationSink
+	// violationSinkFile is the file the violationSink appends records to, which is closed by Start when it returns.
+	violationSinkFile io.Closer
+	// eventBroadcaster sends the Events recorded for violations to the events client, if set.
+	eventBroadcaster record.EventBroadcaster
+	events           typedcorev1.EventsGetter
+
+	// dryRunAuthenticator and dryRunAuthorizer guard the /dryrun endpoint, which is only served on the secure port.
+	dryRunAuthenticator authenticator.Request
+	dryRunAuthorizer    authorizer.Authorizer
+
+	// policyReports writes the PolicyReports of every namespace, if set.
+	policyReports *policyReportController
+
+	metrics         *metrics.PrometheusRecorder
 	metricsRegistry compbasemetrics.KubeRegistry
 }
 
 func (s *Server) Start(ctx context.Context) error {
 	s.informerFactory.Start(ctx.Done())
+	if s.dynamicInformerFactory != nil {
+		s.dynamicInformerFactory.Start(ctx.Done())
+	}
 	logger := klog.FromContext(ctx)
-
-	mux := http.NewServeMux()
-	healthz.InstallHandler(mux, healthz.PingHealthz)
-	healthz.InstallReadyzHandler(mux, healthz.NewInformerSyncHealthz(s.informerFactory))
-	// The webhook is stateless, so it's safe to expose everything on the insecure port for
-	// debugging or proxy purposes. The API server will not connect to an http webhook.
-	mux.HandleFunc("/", s.HandleValidate)
-
-	// Serve the metrics.
-	mux.Handle("/metrics",
-		compbasemetrics.HandlerFor(s.metricsRegistry, compbasemetrics.HandlerOpts{ErrorHandling: compbasemetrics.ContinueOnError}))
+	if s.configFile != "" {
+		go s.watchConfig(ctx)
+	}
+	if s.httpViolationSink != nil {
+		go s.httpViolationSink.Run(ctx)
+	}
+	if s.violationSinkFile != nil {
+		defer s.violationSinkFile.Close()
+	}
+	if s.policyReports != nil {
+		go func() {
+			// Namespaces and pods are listed from the informer cache.
+			if !cache.WaitForCacheSync(ctx.Done(), s.policyReports.synced...) {
+				return
+			}
+			s.policyReports.Run(ctx)
+		}()
+	}
+	if s.eventBroadcaster != nil {
+		s.eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: s.events.Events("")})
+		defer s.eventBroadcaster.Shutdown()
+	}
 
 	if s.insecureServing != nil {
-		if err := s.insecureServing.Serve(mux, 0, ctx.Done()); err != nil {
+		if err := s.insecureServing.Serve(s.newMux(false), 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start insecure server: %w", err)
 		}
 	}
@@ -135,7 +195,7 @@ func (s *Server) Start(ctx context.Context) error {
 	var listenerStoppedCh <-chan struct{}
 	if s.secureServing != nil {
 		var err error
-		shutdownCh, listenerStoppedCh, err = s.secureServing.Serve(mux, 0, ctx.Done())
+		shutdownCh, listenerStoppedCh, err = s.secureServing.Serve(s.newMux(true), 0, ctx.Done())
 		if err != nil {
 			return fmt.Errorf("failed to start secure server: %w", err)
 		}
@@ -151,7 +211,113 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
+// newMux returns the handler of the secure port, or of the insecure port if secure is false.
+func (s *Server) newMux(secure bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	healthz.InstallHandler(mux, healthz.PingHealthz)
+	readyzChecks := []healthz.HealthChecker{healthz.NewInformerSyncHealthz(s.informerFactory)}
+	if s.dynamicInformerFactory != nil {
+		readyzChecks = append(readyzChecks, dynamicInformerSync{s.dynamicInformerFactory})
+	}
+	healthz.InstallReadyzHandler(mux, readyzChecks...)
+	// The webhook is stateless, so it's safe to expose everything on the insecure port for
+	// debugging or proxy purposes. The API server will not connect to an http webhook.
+	mux.HandleFunc("/", s.HandleValidate)
+	mux.HandleFunc("/mutate", s.HandleMutate)
+	// The dry run lists the workloads of a namespace with the permissions of the webhook, so it is
+	// only served on the secure port, to users allowed to list the pods of the namespace.
+	if secure {
+		mux.Handle("/dryrun", s.authorizeDryRun(http.HandlerFunc(s.HandleDryRun)))
+	}
+
+	// Serve the metrics.
+	mux.Handle("/metrics",
+		compbasemetrics.HandlerFor(s.metricsRegistry, compbasemetrics.HandlerOpts{ErrorHandling: compbasemetrics.ContinueOnError}))
+	return mux
+}
+
+// HandleValidate serves validating admission reviews.
 func (s *Server) HandleValidate(w http.ResponseWriter, r *http.Request) {
+	s.handleAdmissionReview(w, r, s.delegate.Load().Validate)
+}
+
+// HandleMutate serves mutating admission reviews, remediating pods and pod controllers
+// towards the enforce policy of their namespace.
+func (s *Server) HandleMutate(w http.ResponseWriter, r *http.Request) {
+	s.handleAdmissionReview(w, r, s.delegate.Load().Mutate)
+}
+
+// dryRunLabels maps the query parameters accepted by HandleDryRun to the namespace labels they stand in for.
+var dryRunLabels = map[string]string{
+	"enforce":         api.EnforceLevelLabel,
+	"enforce-version": api.EnforceVersionLabel,
+	"audit":           api.AuditLevelLabel,
+	"audit-version":   api.AuditVersionLabel,
+	"warn":            api.WarnLevelLabel,
+	"warn-version":    api.WarnVersionLabel,
+}
+
+// HandleDryRun reports how the workloads in a namespace fare against a proposed policy.
+// The namespace is given by the namespace query parameter, and the proposed policy by the
+// enforce, audit and warn level and version parameters, which default as namespace labels do.
+func (s *Server) HandleDryRun(w http.ResponseWriter, r *http.Request) {
+	defer utilruntime.HandleCrash(func(_ interface{}) {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	})
+
+	ctx := r.Context()
+	logger := klog.FromContext(ctx)
+
+	if r.Method != http.MethodGet {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	timeout := defaultDryRunTimeout
+	if t, ok, err := parseTimeout(r); err != nil {
+		// Ignore an invalid timeout.
+		logger.V(2).Info("Invalid timeout", "error", err)
+	} else if ok {
+		timeout = t
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		http.Error(w, "the namespace query parameter is required", http.StatusBadRequest)
+		return
+	}
+	labels := map[string]string{}
+	for param, label := range dryRunLabels {
+		if value := query.Get(param); value != "" {
+			labels[label] = value
+		}
+	}
+	delegate := s.delegate.Load()
+	policy, errs := delegate.PolicyToEvaluate(labels)
+	if len(errs) > 0 {
+		http.Error(w, fmt.Sprintf("invalid policy: %v", errs.ToAggregate()), http.StatusBadRequest)
+		return
+	}
+
+	report, err := delegate.DryRunNamespacePolicy(ctx, namespace, policy)
+	if apierrors.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.Error(err, "dry run failed", "namespace", namespace)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		logger.Error(err, "Failed to encode dry run report")
+	}
+}
+
+func (s *Server) handleAdmissionReview(w http.ResponseWriter, r *http.Request, admit func(context.Context, api.Attributes) *admissionv1.AdmissionResponse) {
 	defer utilruntime.HandleCrash(func(_ interface{}) {
 		// Assume the crash happened before the response was written.
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -221,7 +387,7 @@ func (s *Server) HandleValidate(w http.ResponseWriter, r *http.Request) {
 	logger.V(1).Info("received request", "UID", review.Request.UID, "kind", review.Request.Kind, "resource", review.Request.Resource)
 
 	attributes := api.RequestAttributes(review.Request, codecs.UniversalDeserializer())
-	response := s.delegate.Validate(ctx, attributes)
+	response := admit(ctx, attributes)
 	response.UID = review.Request.UID // Response UID must match request UID
 	review.Response = response
 	writeResponse(w, review)
@@ -233,6 +399,24 @@ type Config struct {
 	InsecureServing   *apiserver.DeprecatedInsecureServingInfo
 	KubeConfig        *restclient.Config
 	PodSecurityConfig *admissionapi.PodSecurityConfiguration
+	// PodSecurityConfigFile is the path the PodSecurityConfig was loaded from, which is reloaded when it changes.
+	PodSecurityConfigFile string
+	// ConfigMapNamespace, ConfigMapName and ConfigMapKey identify a ConfigMap to read the PodSecurityConfig from
+	// instead of a file. The ConfigMap is reloaded when it changes.
+	ConfigMapNamespace, ConfigMapName, ConfigMapKey string
+	// ConfigResourceName is the name of a cluster-scoped PodSecurityConfiguration custom resource to read the
+	// PodSecurityConfig from instead of a file. The resource is reloaded when it changes.
+	ConfigResourceName string
+	// podSecurityConfigData is the content the PodSecurityConfig was loaded from.
+	podSecurityConfigData []byte
+	// EvaluationCacheSize is the number of evaluation results to cache. Caching is disabled if zero.
+	EvaluationCacheSize int
+	// ViolationSink is where a record of every policy violation is emitted, as described by options.Options.
+	ViolationSink string
+	// EmitEvents records Events against pod controllers violating their audit or warn policy.
+	EmitEvents bool
+	// PolicyReportInterval is how often PolicyReports are written for every namespace, if not zero.
+	PolicyReportInterval time.Duration
 }
 
 // LoadConfig loads the Config from the Options.
@@ -243,18 +427,32 @@ func LoadConfig(opts *options.Options) (*Config, error) {
 
 	var c Config
 	opts.SecureServing.ApplyTo(&c.SecureServing)
+	c.EvaluationCacheSize = opts.EvaluationCacheSize
+	c.ViolationSink = opts.ViolationSink
+	c.EmitEvents = opts.EmitEvents
+	c.PolicyReportInterval = opts.PolicyReportInterval
 
 	// Load Kube Client
-	kubeConfig, err := clientcmd.BuildConfigFromFlags("", opts.Kubeconfig)
+	var err error
+	c.KubeConfig, err = kubeconfig.Load(opts.Kubeconfig, opts.ClientQPSLimit, opts.ClientQPSBurst, "podsecurity-webhook")
 	if err != nil {
 		return nil, err
 	}
-	kubeConfig.QPS = opts.ClientQPSLimit
-	kubeConfig.Burst = opts.ClientQPSBurst
-	c.KubeConfig = restclient.AddUserAgent(kubeConfig, "podsecurity-webhook")
 
 	// Load PodSecurity config
-	c.PodSecurityConfig, err = podsecurityconfigloader.LoadFromFile(opts.Config)
+	c.PodSecurityConfigFile = opts.Config
+	if opts.ConfigMap != "" {
+		// The PodSecurityConfig is read from the API server by Setup.
+		c.ConfigMapNamespace, c.ConfigMapName, _ = opts.ConfigMapNamespaceName()
+		c.ConfigMapKey = opts.ConfigMapKey
+		return &c, nil
+	}
+	if opts.ConfigResource != "" {
+		// The PodSecurityConfig is read from the API server by Setup.
+		c.ConfigResourceName = opts.ConfigResource
+		return &c, nil
+	}
+	c.podSecurityConfigData, c.PodSecurityConfig, err = podsecurityconfigloader.LoadFromFileWithData(opts.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +462,18 @@ func LoadConfig(opts *options.Options) (*Config, error) {
 
 // Setup creates an Admission object to handle the admission logic.
 func Setup(c *Config) (*Server, error) {
+	client, err := clientset.NewForConfig(c.KubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(c.KubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return setup(c, client, dynamicClient)
+}
+
+func setup(c *Config, client clientset.Interface, dynamicClient dynamic.Interface) (*Server, error) {
 	s := &Server{
 		secureServing:   c.SecureServing,
 		insecureServing: c.InsecureServing,
@@ -273,41 +483,129 @@ func Setup(c *Config) (*Server, error) {
 		return nil, errors.New("no serving info configured")
 	}
 
-	client, err := clientset.NewForConfig(c.KubeConfig)
-	if err != nil {
-		return nil, err
-	}
 	s.informerFactory = kubeinformers.NewSharedInformerFactory(client, 0 /* no resync */)
 	namespaceInformer := s.informerFactory.Core().V1().Namespaces()
 	namespaceLister := namespaceInformer.Lister()
-
-	evaluator, err := policy.NewEvaluator(policy.DefaultChecks(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("could not create PodSecurityRegistry: %w", err)
+	// PolicyReports evaluate the pods of every namespace periodically, so pods are cached when they are enabled.
+	// Otherwise pods are only listed when namespace labels change, which does not justify caching every pod.
+	podLister := admission.PodListerFromClient(client)
+	var podsSynced cache.InformerSynced
+	if c.PolicyReportInterval > 0 {
+		podInformer := s.informerFactory.Core().V1().Pods()
+		podLister = admission.PodListerFromInformer(podInformer.Lister())
+		podsSynced = podInformer.Informer().HasSynced
 	}
-	metrics := metrics.NewPrometheusRecorder(api.GetAPIVersion())
+
+	s.metrics = metrics.NewPrometheusRecorder(api.GetAPIVersion())
 	s.metricsRegistry = compbasemetrics.NewKubeRegistry()
-	metrics.MustRegister(s.metricsRegistry.MustRegister)
+	s.metrics.MustRegister(s.metricsRegistry.MustRegister)
+
+	if err := s.setupViolationSink(c.ViolationSink); err != nil {
+		return nil, fmt.Errorf("could not set up violation sink: %w", err)
+	}
+	if c.EmitEvents {
+		s.setupEvents(client)
+	}
+	if s.secureServing != nil {
+		if err := s.setupDryRunAuth(client); err != nil {
+			return nil, fmt.Errorf("could not set up dry run authorization: %w", err)
+		}
+	}
+
+	s.newDelegate = func(config *admissionapi.PodSecurityConfiguration) (*admission.Admission, error) {
+		var evaluator policy.Evaluator
+		evaluator, err := policy.NewEvaluator(append(policy.DefaultChecks(), policy.CustomChecks()...), nil,
+			admission.EvaluatorOptions(config)...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create PodSecurityRegistry: %w", err)
+		}
+		if c.EvaluationCacheSize > 0 {
+			evaluator = policy.NewCachingEvaluator(evaluator, c.EvaluationCacheSize, s.metrics)
+		}
 
-	s.delegate = &admission.Admission{
-		Configuration:    c.PodSecurityConfig,
-		Evaluator:        evaluator,
-		Metrics:          metrics,
-		PodSpecExtractor: admission.DefaultPodSpecExtractor{},
-		PodLister:        admission.PodListerFromClient(client),
-		NamespaceGetter:  admission.NamespaceGetterFromListerAndClient(namespaceLister, client),
+		delegate := &admission.Admission{
+			Configuration:    config,
+			Evaluator:        evaluator,
+			Metrics:          s.metrics,
+			ViolationSink:    s.violationSink,
+			PodSpecExtractor: admission.DefaultPodSpecExtractor{},
+			PodLister:        podLister,
+			NamespaceGetter:  admission.NamespaceGetterFromListerAndClient(namespaceLister, client),
+
+			PodControllerLister: admission.PodControllerListerFromClient(client),
+		}
+
+		if err := delegate.CompleteConfiguration(); err != nil {
+			return nil, fmt.Errorf("configuration error: %w", err)
+		}
+		if err := delegate.ValidateConfiguration(); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+		return delegate, nil
 	}
 
-	if err := s.delegate.CompleteConfiguration(); err != nil {
-		return nil, fmt.Errorf("configuration error: %w", err)
+	var err error
+	switch {
+	case c.ConfigMapName != "":
+		err = s.setupConfigMapSource(client, c.ConfigMapNamespace, c.ConfigMapName, c.ConfigMapKey)
+	case c.ConfigResourceName != "":
+		err = s.setupConfigResourceSource(dynamicClient, c.ConfigResourceName)
+	default:
+		err = s.setupConfigFileSource(c.PodSecurityConfigFile, c.podSecurityConfigData, c.PodSecurityConfig)
 	}
-	if err := s.delegate.ValidateConfiguration(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.PolicyReportInterval > 0 {
+		s.policyReports = &policyReportController{
+			client:     dynamicClient,
+			namespaces: namespaceLister,
+			synced:     []cache.InformerSynced{namespaceInformer.Informer().HasSynced, podsSynced},
+			delegate:   s.delegate.Load,
+			interval:   c.PolicyReportInterval,
+			now:        time.Now,
+		}
 	}
 
 	return s, nil
 }
 
+// violationSinkQueueSize is the number of violation records queued to be posted to a collector.
+const violationSinkQueueSize = 1000
+
+// setupViolationSink sets up the violation sink for the --violation-sink option.
+func (s *Server) setupViolationSink(target string) error {
+	switch {
+	case target == "":
+	case target == "stdout":
+		s.violationSink = admission.NewWriterViolationSink(os.Stdout)
+	case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		s.httpViolationSink = admission.NewHTTPViolationSink(target, &http.Client{Timeout: 10 * time.Second}, violationSinkQueueSize)
+		s.violationSink = s.httpViolationSink
+	default:
+		sink, file, err := admission.NewFileViolationSink(target)
+		if err != nil {
+			return err
+		}
+		s.violationSink, s.violationSinkFile = sink, file
+	}
+	return nil
+}
+
+// setupEvents adds a violation sink recording Events against pod controllers violating their policy.
+// The Events are deduplicated and rate-limited per object by the correlator of the event broadcaster.
+func (s *Server) setupEvents(client clientset.Interface) {
+	s.eventBroadcaster = record.NewBroadcaster()
+	s.events = client.CoreV1()
+	recorder := s.eventBroadcaster.NewRecorder(clientgoscheme.Scheme, corev1.EventSource{Component: "pod-security-webhook"})
+	sink := admission.NewEventViolationSink(recorder)
+	if s.violationSink != nil {
+		sink = admission.ViolationSinks{s.violationSink, sink}
+	}
+	s.violationSink = sink
+}
+
 func writeResponse(w http.ResponseWriter, review *admissionv1.AdmissionReview) {
 	// Webhooks should always respond with a 200 HTTP status code when an AdmissionResponse can be sent.
 	// In an error case, the true status code is captured in the response.result.code
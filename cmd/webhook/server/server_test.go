@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetupViolationSink(t *testing.T) {
+	s := &Server{}
+	require.NoError(t, s.setupViolationSink(""))
+	assert.Nil(t, s.violationSink)
+
+	s = &Server{}
+	require.NoError(t, s.setupViolationSink("stdout"))
+	assert.NotNil(t, s.violationSink)
+	assert.Nil(t, s.httpViolationSink)
+
+	s = &Server{}
+	require.NoError(t, s.setupViolationSink("http://localhost:8080/violations"))
+	assert.NotNil(t, s.httpViolationSink)
+	assert.Equal(t, s.httpViolationSink, s.violationSink)
+
+	s = &Server{}
+	require.NoError(t, s.setupViolationSink(filepath.Join(t.TempDir(), "violations.jsonl")))
+	assert.NotNil(t, s.violationSink)
+	require.NotNil(t, s.violationSinkFile)
+	assert.NoError(t, s.violationSinkFile.Close())
+
+	s = &Server{}
+	assert.Error(t, s.setupViolationSink(filepath.Join(t.TempDir(), "missing", "violations.jsonl")))
+}
+
+func TestSetupEvents(t *testing.T) {
+	s := &Server{}
+	require.NoError(t, s.setupViolationSink("stdout"))
+	s.setupEvents(fake.NewSimpleClientset())
+	require.NotNil(t, s.eventBroadcaster)
+	assert.Len(t, s.violationSink, 2)
+}
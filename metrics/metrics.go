@@ -52,6 +52,9 @@ type PrometheusRecorder struct {
 	evaluationsCounter *evaluationsCounter
 	exemptionsCounter  *exemptionsCounter
 	errorsCounter      *metrics.CounterVec
+	cacheLookups       *metrics.CounterVec
+	configReloads      *metrics.CounterVec
+	configReloadTime   *metrics.GaugeVec
 }
 
 var _ Recorder = &PrometheusRecorder{}
@@ -66,11 +69,40 @@ func NewPrometheusRecorder(version api.Version) *PrometheusRecorder {
 		[]string{"fatal", "request_operation", "resource", "subresource"},
 	)
 
+	cacheLookups := metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Name:           "pod_security_evaluation_cache_lookups_total",
+			Help:           "Number of lookups in the evaluation result cache, by whether the result was cached.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	configReloads := metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Name:           "pod_security_config_automatic_reloads_total",
+			Help:           "Number of automatic reloads of the PodSecurity configuration, by status.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"status"},
+	)
+	configReloadTime := metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Name:           "pod_security_config_automatic_reload_last_timestamp_seconds",
+			Help:           "Timestamp of the last automatic reload of the PodSecurity configuration, by status.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"status"},
+	)
+
 	return &PrometheusRecorder{
 		apiVersion:         version,
 		evaluationsCounter: newEvaluationsCounter(),
 		exemptionsCounter:  newExemptionsCounter(),
 		errorsCounter:      errorsCounter,
+		cacheLookups:       cacheLookups,
+		configReloads:      configReloads,
+		configReloadTime:   configReloadTime,
 	}
 }
 
@@ -78,12 +110,18 @@ func (r *PrometheusRecorder) MustRegister(registerFunc func(...metrics.Registera
 	registerFunc(r.evaluationsCounter)
 	registerFunc(r.exemptionsCounter)
 	registerFunc(r.errorsCounter)
+	registerFunc(r.cacheLookups)
+	registerFunc(r.configReloads)
+	registerFunc(r.configReloadTime)
 }
 
 func (r *PrometheusRecorder) Reset() {
 	r.evaluationsCounter.Reset()
 	r.exemptionsCounter.Reset()
 	r.errorsCounter.Reset()
+	r.cacheLookups.Reset()
+	r.configReloads.Reset()
+	r.configReloadTime.Reset()
 }
 
 func (r *PrometheusRecorder) RecordEvaluation(decision Decision, policy api.LevelVersion, evalMode Mode, attrs api.Attributes) {
@@ -126,6 +164,25 @@ func (r *PrometheusRecorder) RecordError(fatal bool, attrs api.Attributes) {
 	).Inc()
 }
 
+// RecordCacheLookup records a lookup in the evaluation result cache, so that its hit rate can be monitored.
+func (r *PrometheusRecorder) RecordCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	r.cacheLookups.WithLabelValues(result).Inc()
+}
+
+// RecordConfigReload records an automatic reload of the configuration file, and whether it succeeded.
+func (r *PrometheusRecorder) RecordConfigReload(success bool) {
+	status := "failure"
+	if success {
+		status = "success"
+	}
+	r.configReloads.WithLabelValues(status).Inc()
+	r.configReloadTime.WithLabelValues(status).SetToCurrentTime()
+}
+
 var (
 	podResource       = corev1.Resource("pods")
 	namespaceResource = corev1.Resource("namespaces")
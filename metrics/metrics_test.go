@@ -150,6 +150,24 @@ func TestRecordError(t *testing.T) {
 	}
 }
 
+func TestRecordCacheLookup(t *testing.T) {
+	recorder := NewPrometheusRecorder(testVersion)
+	registry := testutil.NewFakeKubeRegistry("1.23.0")
+	recorder.MustRegister(registry.MustRegister)
+
+	recorder.RecordCacheLookup(true)
+	recorder.RecordCacheLookup(true)
+	recorder.RecordCacheLookup(false)
+
+	expected := bytes.NewBufferString(`
+	# HELP pod_security_evaluation_cache_lookups_total [ALPHA] Number of lookups in the evaluation result cache, by whether the result was cached.
+	# TYPE pod_security_evaluation_cache_lookups_total counter
+	pod_security_evaluation_cache_lookups_total{result="hit"} 2
+	pod_security_evaluation_cache_lookups_total{result="miss"} 1
+	`)
+	assert.NoError(t, testutil.GatherAndCompare(registry, expected, "pod_security_evaluation_cache_lookups_total"))
+}
+
 func levelVersion(level api.Level, version string) api.LevelVersion {
 	lv := api.LevelVersion{Level: level}
 	var err error
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/pod-security-admission/api"
+)
+
+// AllowListOverride extends or narrows a built-in allow-list.
+type AllowListOverride struct {
+	// Add lists values to allow in addition to the built-in allow-list.
+	Add []string
+	// Remove lists values of the built-in allow-list to forbid.
+	Remove []string
+}
+
+// apply returns the allow-list with the override applied, without modifying it.
+func (o AllowListOverride) apply(allowed sets.Set[string]) sets.Set[string] {
+	if len(o.Add) == 0 && len(o.Remove) == 0 {
+		return allowed
+	}
+	return allowed.Union(sets.New(o.Add...)).Difference(sets.New(o.Remove...))
+}
+
+// LevelAllowLists overrides the allow-lists of checks evaluated at a level.
+// Unset overrides of the restricted level default to the overrides of the baseline level,
+// and unset overrides of a custom level default to the overrides of its base level.
+type LevelAllowLists struct {
+	// Level is the level the overrides apply to. Must not be Privileged.
+	Level api.Level
+	// Sysctls overrides the sysctls allowed by the sysctls check.
+	Sysctls AllowListOverride
+	// Capabilities overrides the capabilities that may be added according to the capabilities_baseline check.
+	// The capabilities_baseline check is overridden by capabilities_restricted at the restricted level,
+	// so Capabilities overrides have no effect at levels evaluating capabilities_restricted, such as restricted.
+	Capabilities AllowListOverride
+	// VolumeTypes overrides the volume types allowed by the restrictedVolumes check,
+	// e.g. "nfs" or "persistentVolumeClaim".
+	VolumeTypes AllowListOverride
+}
+
+func validateAllowLists(lists []LevelAllowLists, customLevels []CustomLevel) error {
+	levels := sets.New(api.LevelBaseline, api.LevelRestricted)
+	for _, level := range customLevels {
+		levels.Insert(level.Name)
+	}
+	seen := sets.New[api.Level]()
+	for _, l := range lists {
+		if !levels.Has(l.Level) {
+			return fmt.Errorf("allow-lists: invalid level %q", l.Level)
+		}
+		if seen.Has(l.Level) {
+			return fmt.Errorf("allow-lists: multiple overrides for level %s", l.Level)
+		}
+		seen.Insert(l.Level)
+		for _, t := range append(append([]string(nil), l.VolumeTypes.Add...), l.VolumeTypes.Remove...) {
+			if !volumeTypes.Has(t) {
+				return fmt.Errorf("allow-lists: level %s: unknown volume type %q", l.Level, t)
+			}
+		}
+	}
+	return nil
+}
+
+// populateAllowLists indexes the overrides by level. Overrides a level leaves unset default
+// to the overrides of the level it extends: baseline for restricted, the base level for custom levels.
+// This keeps restricted at least as strict as baseline when only baseline allow-lists are overridden.
+func populateAllowLists(r *checkRegistry, lists []LevelAllowLists, customLevels []CustomLevel) {
+	if len(lists) == 0 {
+		return
+	}
+	r.allowLists = map[api.Level]*LevelAllowLists{}
+	for i := range lists {
+		r.allowLists[lists[i].Level] = &lists[i]
+	}
+	inherit(r.allowLists, api.LevelRestricted, api.LevelBaseline)
+	for _, level := range customLevels {
+		inherit(r.allowLists, level.Name, level.Base)
+	}
+}
+
+// inherit fills the overrides of level left unset from the overrides of base.
+func inherit(lists map[api.Level]*LevelAllowLists, level, base api.Level) {
+	baseLists, ok := lists[base]
+	if !ok {
+		return
+	}
+	levelLists, ok := lists[level]
+	if !ok {
+		lists[level] = baseLists
+		return
+	}
+	merged := *levelLists
+	merged.Sysctls = merged.Sysctls.or(baseLists.Sysctls)
+	merged.Capabilities = merged.Capabilities.or(baseLists.Capabilities)
+	merged.VolumeTypes = merged.VolumeTypes.or(baseLists.VolumeTypes)
+	lists[level] = &merged
+}
+
+// or returns the override, or fallback if the override is unset.
+func (o AllowListOverride) or(fallback AllowListOverride) AllowListOverride {
+	if len(o.Add) == 0 && len(o.Remove) == 0 {
+		return fallback
+	}
+	return o
+}
+
+// withAllowLists passes the allow-list overrides of the evaluated level to the checks.
+func withAllowLists(lists *LevelAllowLists) Option {
+	return func(opt options) options {
+		opt.allowLists = lists
+		return opt
+	}
+}
+
+func (o options) allowedSysctls(allowed sets.Set[string]) sets.Set[string] {
+	if o.allowLists == nil {
+		return allowed
+	}
+	return o.allowLists.Sysctls.apply(allowed)
+}
+
+func (o options) allowedCapabilities(allowed sets.Set[string]) sets.Set[string] {
+	if o.allowLists == nil {
+		return allowed
+	}
+	return o.allowLists.Capabilities.apply(allowed)
+}
+
+func (o options) allowedVolumeTypes(allowed sets.Set[string]) sets.Set[string] {
+	if o.allowLists == nil {
+		return allowed
+	}
+	return o.allowLists.VolumeTypes.apply(allowed)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/pod-security-admission/api"
+)
+
+func TestAllowLists(t *testing.T) {
+	evaluator, err := NewEvaluator(DefaultChecks(), nil,
+		WithCustomLevels(
+			CustomLevel{Name: "restricted-inherited", Base: api.LevelRestricted},
+			CustomLevel{Name: "restricted-own", Base: api.LevelRestricted},
+		),
+		WithAllowLists(
+			LevelAllowLists{
+				Level:        api.LevelBaseline,
+				Sysctls:      AllowListOverride{Add: []string{"net.core.somaxconn"}, Remove: []string{"kernel.shm_rmid_forced"}},
+				Capabilities: AllowListOverride{Add: []string{"NET_RAW"}, Remove: []string{"CHOWN"}},
+			},
+			LevelAllowLists{
+				Level:       api.LevelRestricted,
+				VolumeTypes: AllowListOverride{Add: []string{"nfs"}, Remove: []string{"csi"}},
+			},
+			LevelAllowLists{Level: "restricted-own", VolumeTypes: AllowListOverride{Remove: []string{"nfs"}}},
+		),
+	)
+	require.NoError(t, err)
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{
+			{Name: "net.core.somaxconn"},
+			{Name: "kernel.shm_rmid_forced"},
+		}},
+		Containers: []corev1.Container{{
+			Name: "a",
+			SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_RAW", "CHOWN"},
+			}},
+		}},
+		Volumes: []corev1.Volume{
+			{Name: "nfs", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{}}},
+			{Name: "csi", VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{}}},
+		},
+	}}
+	details := func(level api.Level) map[CheckID]string {
+		details := map[CheckID]string{}
+		for _, result := range evaluator.EvaluatePod(api.LevelVersion{Level: level, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec) {
+			switch result.CheckID {
+			case "sysctls", "capabilities_baseline", "restrictedVolumes":
+				if !result.Allowed {
+					details[result.CheckID] = result.ForbiddenDetail
+				}
+			}
+		}
+		return details
+	}
+
+	assert.Equal(t, map[CheckID]string{
+		"sysctls":               `kernel.shm_rmid_forced`,
+		"capabilities_baseline": `container "a" must not include "CHOWN" in securityContext.capabilities.add`,
+	}, details(api.LevelBaseline))
+	// restricted inherits the sysctls allowed at baseline.
+	restrictedDetails := map[CheckID]string{
+		"sysctls":           `kernel.shm_rmid_forced`,
+		"restrictedVolumes": `volume "csi" uses restricted volume type "csi"`,
+	}
+	assert.Equal(t, restrictedDetails, details(api.LevelRestricted))
+	assert.Equal(t, restrictedDetails, details("restricted-inherited"))
+	assert.Equal(t, map[CheckID]string{
+		"sysctls":           `kernel.shm_rmid_forced`,
+		"restrictedVolumes": `volume "nfs" uses restricted volume type "nfs"`,
+	}, details("restricted-own"))
+}
+
+func TestAllowLists_Invalid(t *testing.T) {
+	tests := []struct {
+		lists       LevelAllowLists
+		expectedErr string
+	}{
+		{LevelAllowLists{Level: api.LevelPrivileged}, `allow-lists: invalid level "privileged"`},
+		{LevelAllowLists{Level: "unknown"}, `allow-lists: invalid level "unknown"`},
+		{LevelAllowLists{Level: api.LevelRestricted, VolumeTypes: AllowListOverride{Add: []string{"nfs4"}}}, `allow-lists: level restricted: unknown volume type "nfs4"`},
+	}
+	for _, tc := range tests {
+		_, err := NewEvaluator(DefaultChecks(), nil, WithAllowLists(tc.lists))
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+
+	_, err := NewEvaluator(DefaultChecks(), nil, WithAllowLists(LevelAllowLists{Level: api.LevelBaseline}, LevelAllowLists{Level: api.LevelBaseline}))
+	assert.EqualError(t, err, "allow-lists: multiple overrides for level baseline")
+}
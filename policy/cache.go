@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/utils/lru"
+)
+
+// CacheRecorder records the lookups of a caching Evaluator.
+type CacheRecorder interface {
+	RecordCacheLookup(hit bool)
+}
+
+// podMetadataReader is implemented by Evaluators that know whether their checks read pod metadata
+// other than the labels and annotations, such as the name or owner references.
+type podMetadataReader interface {
+	readsPodMetadata() bool
+}
+
+// cachingEvaluator is an Evaluator that caches the results of a delegate Evaluator.
+type cachingEvaluator struct {
+	delegate Evaluator
+	cache    *lru.Cache
+	recorder CacheRecorder
+	// fullMetadata is set if the delegate may read any pod metadata, which is then all hashed into the cache key.
+	fullMetadata bool
+}
+
+// cacheKey identifies an evaluation. The pod is identified by a hash of its labels, annotations and spec,
+// so that the identical pods of a controller share an entry, or of its whole metadata and spec if the
+// checks may read other metadata.
+type cacheKey struct {
+	lv   api.LevelVersion
+	opts options
+	pod  [sha256.Size]byte
+}
+
+// cachedPod is the part of the pod that is hashed into the cache key.
+type cachedPod struct {
+	Metadata    *metav1.ObjectMeta `json:"metadata,omitempty"`
+	Labels      map[string]string  `json:"labels,omitempty"`
+	Annotations map[string]string  `json:"annotations,omitempty"`
+	Spec        *corev1.PodSpec    `json:"spec,omitempty"`
+}
+
+// NewCachingEvaluator returns an Evaluator that keeps the results of the delegate Evaluator in a
+// least-recently-used cache of the given size, and records every lookup with the recorder, which may be nil.
+// Results are keyed by the level & version, the options and the labels, annotations and spec of the pod.
+// If the delegate may read other pod metadata, such as the name read by CEL checks or checks registered
+// with RegisterCheck, the whole pod metadata is part of the key instead, and only evaluations of the same pod
+// are shared. Evaluators not constructed by NewEvaluator are assumed to read any pod metadata.
+// The returned Evaluator implements Explainer if the delegate does; explanations are not cached.
+func NewCachingEvaluator(delegate Evaluator, size int, recorder CacheRecorder) Evaluator {
+	reader, ok := delegate.(podMetadataReader)
+	e := &cachingEvaluator{
+		delegate:     delegate,
+		cache:        lru.New(size),
+		recorder:     recorder,
+		fullMetadata: !ok || reader.readsPodMetadata(),
+	}
+	if _, ok := delegate.(Explainer); ok {
+		return &explainingCachingEvaluator{e}
+	}
+	return e
+}
+
+// explainingCachingEvaluator is a cachingEvaluator that forwards explanations to its delegate.
+type explainingCachingEvaluator struct {
+	*cachingEvaluator
+}
+
+func (e *explainingCachingEvaluator) ExplainPod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) Explanation {
+	return e.delegate.(Explainer).ExplainPod(lv, podMetadata, podSpec, opts...)
+}
+
+func (e *cachingEvaluator) EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) []CheckResult {
+	key, ok := newCacheKey(lv, podMetadata, podSpec, opts, e.fullMetadata)
+	if !ok {
+		return e.delegate.EvaluatePod(lv, podMetadata, podSpec, opts...)
+	}
+	if cached, hit := e.cache.Get(key); hit {
+		e.record(true)
+		return append([]CheckResult(nil), cached.([]CheckResult)...)
+	}
+	e.record(false)
+	results := e.delegate.EvaluatePod(lv, podMetadata, podSpec, opts...)
+	e.cache.Add(key, results)
+	return append([]CheckResult(nil), results...)
+}
+
+func (e *cachingEvaluator) record(hit bool) {
+	if e.recorder != nil {
+		e.recorder.RecordCacheLookup(hit)
+	}
+}
+
+// newCacheKey returns the cache key of the evaluation, or false if the pod cannot be hashed.
+func newCacheKey(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts []Option, fullMetadata bool) (cacheKey, bool) {
+	key := cacheKey{lv: lv}
+	for _, opt := range opts {
+		if opt != nil {
+			key.opts = opt(key.opts)
+		}
+	}
+	pod := cachedPod{Spec: podSpec}
+	if fullMetadata {
+		pod.Metadata = podMetadata
+	} else if podMetadata != nil {
+		pod.Labels = podMetadata.Labels
+		pod.Annotations = podMetadata.Annotations
+	}
+	data, err := json.Marshal(pod)
+	if err != nil {
+		return cacheKey{}, false
+	}
+	key.pod = sha256.Sum256(data)
+	return key, true
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingEvaluator(t *testing.T) {
+	reg, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(t, err)
+	delegate := &countingMetadataEvaluator{countingEvaluator{delegate: reg}}
+	recorder := &fakeCacheRecorder{}
+	evaluator := NewCachingEvaluator(delegate, 2, recorder)
+
+	restricted := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+	baseline := api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}
+	pod := largePod(2)
+	pod.Name = "replica-1"
+	replica := pod.DeepCopy()
+	replica.Name = "replica-2"
+	replica.UID = "uid-2"
+
+	expected := reg.EvaluatePod(restricted, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, expected, evaluator.EvaluatePod(restricted, &pod.ObjectMeta, &pod.Spec))
+	assert.Equal(t, 1, delegate.calls)
+
+	// Replicas only differ in their name and UID.
+	assert.Equal(t, expected, evaluator.EvaluatePod(restricted, &replica.ObjectMeta, &replica.Spec))
+	assert.Equal(t, 1, delegate.calls)
+
+	// The level, the options and the labels, annotations and spec of the pod are part of the key.
+	evaluator.EvaluatePod(baseline, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, 2, delegate.calls)
+	withFieldErrors := evaluator.EvaluatePod(restricted, &pod.ObjectMeta, &pod.Spec, WithFieldErrors())
+	assert.Equal(t, reg.EvaluatePod(restricted, &pod.ObjectMeta, &pod.Spec, WithFieldErrors()), withFieldErrors)
+	assert.Equal(t, 3, delegate.calls)
+
+	relabeled := pod.DeepCopy()
+	relabeled.Labels = map[string]string{"app": "other"}
+	evaluator.EvaluatePod(restricted, &relabeled.ObjectMeta, &relabeled.Spec)
+	assert.Equal(t, 4, delegate.calls)
+
+	fixed := pod.DeepCopy()
+	fixed.Spec.Containers[0].SecurityContext.Capabilities = nil
+	evaluator.EvaluatePod(restricted, &fixed.ObjectMeta, &fixed.Spec)
+	assert.Equal(t, 5, delegate.calls)
+
+	// The first evaluation was evicted by the later ones.
+	evaluator.EvaluatePod(restricted, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, 6, delegate.calls)
+
+	assert.Equal(t, 1, recorder.hits)
+	assert.Equal(t, 6, recorder.misses)
+}
+
+func TestCachingEvaluator_PodMetadata(t *testing.T) {
+	reg, err := NewEvaluator(DefaultChecks(), nil, WithCELChecks(CELCheck{
+		ID:             "canaryName",
+		Level:          api.LevelBaseline,
+		MinimumVersion: api.MajorMinorVersion(1, 0),
+		Expression:     "!podMetadata.name.startsWith('canary-')",
+		Reason:         "canary pod",
+	}))
+	require.NoError(t, err)
+	evaluator := NewCachingEvaluator(reg, 10, nil)
+
+	baseline := api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}
+	pod := largePod(1)
+	pod.Name = "stable-1"
+	canary := pod.DeepCopy()
+	canary.Name = "canary-1"
+
+	// CEL checks read the name, so pods that only differ in their name do not share an entry.
+	assert.True(t, AggregateCheckResults(evaluator.EvaluatePod(baseline, &pod.ObjectMeta, &pod.Spec)).Allowed)
+	result := AggregateCheckResults(evaluator.EvaluatePod(baseline, &canary.ObjectMeta, &canary.Spec))
+	assert.Equal(t, []string{"canary pod"}, result.ForbiddenReasons)
+
+	// Evaluators that do not report the metadata they read are keyed by the whole metadata.
+	other := NewCachingEvaluator(&countingEvaluator{delegate: reg}, 10, nil)
+	assert.True(t, AggregateCheckResults(other.EvaluatePod(baseline, &pod.ObjectMeta, &pod.Spec)).Allowed)
+	assert.False(t, AggregateCheckResults(other.EvaluatePod(baseline, &canary.ObjectMeta, &canary.Spec)).Allowed)
+}
+
+func TestCachingEvaluator_Explainer(t *testing.T) {
+	reg, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(t, err)
+	evaluator := NewCachingEvaluator(reg, 10, nil)
+	explainer, ok := evaluator.(Explainer)
+	require.True(t, ok, "the explanations of the delegate are forwarded")
+
+	restricted := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+	pod := largePod(1)
+	expected := reg.ExplainPod(restricted, &pod.ObjectMeta, &pod.Spec)
+	explanation := explainer.ExplainPod(restricted, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, expected.Evaluated, explanation.Evaluated)
+	assert.Len(t, explanation.Checks, len(expected.Checks))
+
+	_, ok = NewCachingEvaluator(&countingEvaluator{delegate: reg}, 10, nil).(Explainer)
+	assert.False(t, ok)
+}
+
+func TestCachingEvaluator_Concurrent(t *testing.T) {
+	reg, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(t, err)
+	evaluator := NewCachingEvaluator(reg, 4, nil)
+	lv := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+
+	var pods []*corev1.Pod
+	for i := 0; i < 8; i++ {
+		pod := largePod(1)
+		pod.Labels = map[string]string{"pod": fmt.Sprint(i)}
+		pods = append(pods, pod)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(pod *corev1.Pod) {
+			defer wg.Done()
+			assert.Equal(t, reg.EvaluatePod(lv, &pod.ObjectMeta, &pod.Spec), evaluator.EvaluatePod(lv, &pod.ObjectMeta, &pod.Spec))
+		}(pods[i%len(pods)])
+	}
+	wg.Wait()
+}
+
+type countingEvaluator struct {
+	delegate Evaluator
+	calls    int
+}
+
+// countingMetadataEvaluator is a countingEvaluator that reports the pod metadata read by its delegate.
+type countingMetadataEvaluator struct {
+	countingEvaluator
+}
+
+func (e *countingMetadataEvaluator) readsPodMetadata() bool {
+	return e.delegate.(podMetadataReader).readsPodMetadata()
+}
+
+func (e *countingEvaluator) EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) []CheckResult {
+	e.calls++
+	return e.delegate.EvaluatePod(lv, podMetadata, podSpec, opts...)
+}
+
+type fakeCacheRecorder struct {
+	hits, misses int
+}
+
+func (r *fakeCacheRecorder) RecordCacheLookup(hit bool) {
+	if hit {
+		r.hits++
+	} else {
+		r.misses++
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+// celCostLimit bounds the cost of evaluating a CEL expression against a pod.
+const celCostLimit = 1000000
+
+// CELCheck defines a check whose logic is a CEL expression rather than a CheckPodFn.
+// The expressions may refer to the podMetadata and podSpec variables, which hold the pod
+// metadata and spec as they are serialized to JSON, so unset fields must be tested with has().
+type CELCheck struct {
+	// ID is the unique ID of the check.
+	ID CheckID
+	// Level is the policy level this check belongs to. Must be Baseline or Restricted.
+	Level api.Level
+	// MinimumVersion is the first policy version this check applies to. Must not be "latest".
+	MinimumVersion api.Version
+	// Expression evaluates to true if the pod is allowed.
+	// Example: podSpec.containers.all(c, c.image.startsWith('registry.example.com/'))
+	Expression string
+	// Reason is the ForbiddenReason of pods that are not allowed.
+	Reason string
+	// MessageExpression optionally evaluates to the ForbiddenDetail of pods that are not allowed.
+	MessageExpression string
+	// FieldPath is the path of the field reported in field errors, e.g. "spec.containers[*].image".
+	// Defaults to "spec".
+	FieldPath string
+}
+
+func newCELEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("podMetadata", cel.DynType),
+		cel.Variable("podSpec", cel.DynType),
+		ext.Strings(),
+		ext.Lists(),
+		ext.Sets(),
+	)
+}
+
+// compileCELCheck compiles the expressions of the CEL check into a Check with a single version.
+func compileCELCheck(env *cel.Env, c CELCheck) (Check, error) {
+	compile := func(name, expression string, outputType *cel.Type) (cel.Program, error) {
+		ast, issues := env.Compile(expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("check %s: invalid %s: %w", c.ID, name, issues.Err())
+		}
+		if t := ast.OutputType(); !t.IsExactType(outputType) && !t.IsExactType(cel.DynType) {
+			return nil, fmt.Errorf("check %s: %s must evaluate to %s, not %s", c.ID, name, outputType, t)
+		}
+		return env.Program(ast, cel.CostLimit(celCostLimit))
+	}
+
+	if c.Reason == "" {
+		return Check{}, fmt.Errorf("check %s: reason must not be empty", c.ID)
+	}
+	program, err := compile("expression", c.Expression, cel.BoolType)
+	if err != nil {
+		return Check{}, err
+	}
+	var messageProgram cel.Program
+	if c.MessageExpression != "" {
+		if messageProgram, err = compile("messageExpression", c.MessageExpression, cel.StringType); err != nil {
+			return Check{}, err
+		}
+	}
+	fieldPath := specPath
+	if c.FieldPath != "" {
+		fieldPath = field.NewPath(c.FieldPath)
+	}
+
+	checkPod := func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+		forbidden := func(detail string) CheckResult {
+			result := CheckResult{Allowed: false, ForbiddenReason: c.Reason, ForbiddenDetail: detail}
+			if opts.withFieldErrors {
+				result.ErrList = &field.ErrorList{field.Forbidden(fieldPath, detail)}
+			}
+			return result
+		}
+
+		vars, err := celVariables(podMetadata, podSpec)
+		if err != nil {
+			return forbidden(fmt.Sprintf("failed to evaluate: %v", err))
+		}
+		out, _, err := program.Eval(vars)
+		if err != nil {
+			return forbidden(fmt.Sprintf("failed to evaluate: %v", err))
+		}
+		allowed, ok := out.Value().(bool)
+		if !ok {
+			return forbidden(fmt.Sprintf("failed to evaluate: expression returned %v, not a bool", out.Type()))
+		}
+		if allowed {
+			return CheckResult{Allowed: true}
+		}
+		if messageProgram == nil {
+			return forbidden("")
+		}
+		message, _, err := messageProgram.Eval(vars)
+		if err != nil {
+			return forbidden(fmt.Sprintf("failed to evaluate messageExpression: %v", err))
+		}
+		detail, ok := message.Value().(string)
+		if !ok {
+			return forbidden(fmt.Sprintf("failed to evaluate messageExpression: returned %v, not a string", message.Type()))
+		}
+		return forbidden(detail)
+	}
+
+	return Check{
+		ID:    c.ID,
+		Level: c.Level,
+		Versions: []VersionedCheck{{
+			MinimumVersion: c.MinimumVersion,
+			CheckPod:       withOptions(checkPod),
+		}},
+	}, nil
+}
+
+// celVariables converts the pod metadata and spec to the variables of CEL expressions.
+func celVariables(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec) (map[string]interface{}, error) {
+	vars := map[string]interface{}{
+		"podMetadata": map[string]interface{}{},
+		"podSpec":     map[string]interface{}{},
+	}
+	if podMetadata != nil {
+		metadata, err := runtime.DefaultUnstructuredConverter.ToUnstructured(podMetadata)
+		if err != nil {
+			return nil, err
+		}
+		vars["podMetadata"] = metadata
+	}
+	if podSpec != nil {
+		spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(podSpec)
+		if err != nil {
+			return nil, err
+		}
+		vars["podSpec"] = spec
+	}
+	return vars, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+func TestCELChecks(t *testing.T) {
+	trustedRegistry := CELCheck{
+		ID:                "trustedRegistry",
+		Level:             api.LevelRestricted,
+		MinimumVersion:    api.MajorMinorVersion(1, 0),
+		Expression:        "podSpec.containers.all(c, c.image.startsWith('registry.example.com/'))",
+		Reason:            "untrusted registry",
+		MessageExpression: "podSpec.containers.filter(c, !c.image.startsWith('registry.example.com/')).map(c, c.image).join(', ')",
+		FieldPath:         "spec.containers[*].image",
+	}
+	teamLabel := CELCheck{
+		ID:             "teamLabel",
+		Level:          api.LevelBaseline,
+		MinimumVersion: api.MajorMinorVersion(1, 20),
+		Expression:     "has(podMetadata.labels) && 'team' in podMetadata.labels",
+		Reason:         "missing team label",
+	}
+	evaluator, err := NewEvaluator(nil, nil, WithCELChecks(trustedRegistry, teamLabel))
+	require.NoError(t, err)
+
+	podMetadata := &metav1.ObjectMeta{Labels: map[string]string{"team": "a"}}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "a", Image: "registry.example.com/a"},
+		{Name: "b", Image: "docker.io/b"},
+		{Name: "c", Image: "quay.io/c"},
+	}}
+	results := evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}, podMetadata, podSpec, WithFieldErrors())
+	require.Len(t, results, 2)
+	assert.Equal(t, CheckID("teamLabel"), results[0].CheckID)
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, CheckID("trustedRegistry"), results[1].CheckID)
+	assert.False(t, results[1].Allowed)
+	assert.Equal(t, "untrusted registry", results[1].ForbiddenReason)
+	assert.Equal(t, "docker.io/b, quay.io/c", results[1].ForbiddenDetail)
+	require.NotNil(t, results[1].ErrList)
+	assert.Equal(t, field.ErrorList{field.Forbidden(field.NewPath("spec.containers[*].image"), "docker.io/b, quay.io/c")}, *results[1].ErrList)
+
+	results = evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelBaseline, Version: api.MajorMinorVersion(1, 19)}, podMetadata, podSpec)
+	assert.Empty(t, results, "teamLabel is not assigned to v1.19")
+
+	results = evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}, &metav1.ObjectMeta{}, podSpec)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Allowed)
+	assert.Equal(t, "missing team label", results[0].ForbiddenReason)
+	assert.Empty(t, results[0].ForbiddenDetail)
+	assert.Nil(t, results[0].ErrList)
+}
+
+func TestCELChecksEvaluationError(t *testing.T) {
+	evaluator, err := NewEvaluator(nil, nil, WithCELChecks(CELCheck{
+		ID:             "hostname",
+		Level:          api.LevelBaseline,
+		MinimumVersion: api.MajorMinorVersion(1, 0),
+		Expression:     "podSpec.hostname != 'forbidden'",
+		Reason:         "forbidden hostname",
+	}))
+	require.NoError(t, err)
+
+	results := evaluator.EvaluatePod(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}, &metav1.ObjectMeta{}, &corev1.PodSpec{})
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Allowed, "evaluation errors must fail closed")
+	assert.Equal(t, "forbidden hostname", results[0].ForbiddenReason)
+	assert.Contains(t, results[0].ForbiddenDetail, "failed to evaluate: no such key: hostname")
+}
+
+func TestCELChecksInvalid(t *testing.T) {
+	valid := CELCheck{
+		ID:             "valid",
+		Level:          api.LevelBaseline,
+		MinimumVersion: api.MajorMinorVersion(1, 0),
+		Expression:     "true",
+		Reason:         "valid",
+	}
+	testcases := []struct {
+		name        string
+		modify      func(*CELCheck)
+		expectedErr string
+	}{{
+		name:        "syntax error",
+		modify:      func(c *CELCheck) { c.Expression = "podSpec.containers.all(" },
+		expectedErr: "check valid: invalid expression: ",
+	}, {
+		name:        "non-bool expression",
+		modify:      func(c *CELCheck) { c.Expression = "'allowed'" },
+		expectedErr: "check valid: expression must evaluate to bool, not string",
+	}, {
+		name:        "non-string message",
+		modify:      func(c *CELCheck) { c.MessageExpression = "1" },
+		expectedErr: "check valid: messageExpression must evaluate to string, not int",
+	}, {
+		name:        "missing reason",
+		modify:      func(c *CELCheck) { c.Reason = "" },
+		expectedErr: "check valid: reason must not be empty",
+	}, {
+		name:        "built-in ID",
+		modify:      func(c *CELCheck) { c.ID = "privileged" },
+		expectedErr: "multiple checks registered for ID privileged",
+	}, {
+		name:        "latest version",
+		modify:      func(c *CELCheck) { c.MinimumVersion = api.LatestVersion() },
+		expectedErr: "check valid: version cannot be 'latest'",
+	}, {
+		name:        "invalid level",
+		modify:      func(c *CELCheck) { c.Level = api.LevelPrivileged },
+		expectedErr: "check valid: invalid level privileged",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid
+			tc.modify(&c)
+			_, err := NewEvaluator(DefaultChecks(), nil, WithCELChecks(c))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Pods must not automatically mount a service account token.
+The service account setting is not known at admission, so the pod must opt out explicitly.
+This check is only evaluated if HardenedChecks are added to the Evaluator.
+
+**Restricted Fields:**
+spec.automountServiceAccountToken
+
+**Allowed Values:**
+false
+*/
+
+func init() {
+	addHardenedCheck(CheckAutomountServiceAccountToken)
+}
+
+// CheckAutomountServiceAccountToken returns a restricted level check
+// that requires automountServiceAccountToken=false in 1.0+
+func CheckAutomountServiceAccountToken() Check {
+	return Check{
+		ID:    "automountServiceAccountToken",
+		Level: api.LevelRestricted,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod:       withOptions(automountServiceAccountTokenV1Dot0),
+			},
+		},
+	}
+}
+
+func automountServiceAccountTokenV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	if podSpec.AutomountServiceAccountToken != nil && !*podSpec.AutomountServiceAccountToken {
+		return CheckResult{Allowed: true}
+	}
+
+	var errList *field.ErrorList
+	if opts.withFieldErrors {
+		if podSpec.AutomountServiceAccountToken == nil {
+			errList = &field.ErrorList{required(automountServiceAccountTokenPath)}
+		} else {
+			errList = &field.ErrorList{withBadValue(forbidden(automountServiceAccountTokenPath), true)}
+		}
+	}
+	return CheckResult{
+		Allowed:         false,
+		ForbiddenReason: "service account token automount",
+		ForbiddenDetail: "pod must set spec.automountServiceAccountToken=false",
+		ErrList:         errList,
+	}
+}
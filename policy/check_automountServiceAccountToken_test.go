@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestAutomountServiceAccountToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		opts          options
+		allowed       bool
+		expectErrList field.ErrorList
+	}{
+		{
+			name:    "automountServiceAccountToken=false",
+			pod:     &corev1.Pod{Spec: corev1.PodSpec{AutomountServiceAccountToken: ptr.To(false)}},
+			allowed: true,
+		},
+		{
+			name: "automountServiceAccountToken unset",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{}},
+		},
+		{
+			name: "automountServiceAccountToken unset, enable field error list",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{}},
+			opts: options{
+				withFieldErrors: true,
+			},
+			expectErrList: field.ErrorList{
+				{Type: field.ErrorTypeRequired, Field: "spec.automountServiceAccountToken", BadValue: ""},
+			},
+		},
+		{
+			name: "automountServiceAccountToken=true, enable field error list",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{AutomountServiceAccountToken: ptr.To(true)}},
+			opts: options{
+				withFieldErrors: true,
+			},
+			expectErrList: field.ErrorList{
+				{Type: field.ErrorTypeForbidden, Field: "spec.automountServiceAccountToken", BadValue: true},
+			},
+		},
+	}
+
+	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(field.Error{}, "Detail")}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := automountServiceAccountTokenV1Dot0(&tc.pod.ObjectMeta, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.allowed {
+				t.Fatalf("expected allowed=%v, got %v", tc.allowed, result.Allowed)
+			}
+			if tc.allowed {
+				return
+			}
+			if e, a := `service account token automount`, result.ForbiddenReason; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if e, a := `pod must set spec.automountServiceAccountToken=false`, result.ForbiddenDetail; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if result.ErrList != nil {
+				if diff := cmp.Diff(tc.expectErrList, *result.ErrList, cmpOpts...); diff != "" {
+					t.Errorf("unexpected field errors (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -78,6 +78,7 @@ var (
 )
 
 func capabilitiesBaselineV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	allowedCapabilities := opts.allowedCapabilities(sets.Set[string](capabilities_allowed_1_0))
 	badContainers := NewViolations(opts.withFieldErrors)
 	nonDefaultCapabilities := sets.NewString()
 	visitContainers(podSpec, opts, func(container *corev1.Container, path *field.Path) {
@@ -86,7 +87,7 @@ func capabilitiesBaselineV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.
 			if opts.withFieldErrors {
 				forbiddenValue := sets.NewString()
 				for _, c := range container.SecurityContext.Capabilities.Add {
-					if !capabilities_allowed_1_0.Has(string(c)) {
+					if !allowedCapabilities.Has(string(c)) {
 						valid = false
 						nonDefaultCapabilities.Insert(string(c))
 						forbiddenValue.Insert(string(c))
@@ -97,7 +98,7 @@ func capabilitiesBaselineV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.
 				}
 			} else {
 				for _, c := range container.SecurityContext.Capabilities.Add {
-					if !capabilities_allowed_1_0.Has(string(c)) {
+					if !allowedCapabilities.Has(string(c)) {
 						valid = false
 						nonDefaultCapabilities.Insert(string(c))
 					}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Images must be pulled from trusted registries, and must not use mutable tags.
+This check is not part of any level unless it is added to the Evaluator.
+
+Restricted Fields:
+spec.containers[*].image
+spec.initContainers[*].image
+spec.ephemeralContainers[*].image
+
+Allowed Values: images from the allowed registries, without a disallowed tag,
+referenced by digest if digests are required
+*/
+
+// ImagePolicy configures the check returned by CheckImages.
+type ImagePolicy struct {
+	// Level is the policy level the check belongs to. Must be Baseline or Restricted.
+	Level api.Level
+	// AllowedRegistries lists the prefixes images must start with, e.g. "registry.example.com/".
+	// Prefixes match whole path components, so "registry.example.com" does not match "registry.example.community/app".
+	// Images are normalized before they are matched, so "nginx" matches "docker.io/library/".
+	// If empty, images may be pulled from any registry.
+	AllowedRegistries []string
+	// DisallowedTags lists the tags images must not use, e.g. "latest".
+	// Images without a tag or digest are treated as using the "latest" tag.
+	DisallowedTags []string
+	// RequireDigest requires images to be referenced by digest.
+	RequireDigest bool
+}
+
+// CheckImages returns a check that enforces the image policy in 1.0+.
+// The check has the ID "images".
+func CheckImages(p ImagePolicy) Check {
+	p.AllowedRegistries = append([]string(nil), p.AllowedRegistries...)
+	p.DisallowedTags = append([]string(nil), p.DisallowedTags...)
+	return Check{
+		ID:    "images",
+		Level: p.Level,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod: withOptions(func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+					return imagesV1Dot0(p, podSpec, opts)
+				}),
+			},
+		},
+	}
+}
+
+func imagesV1Dot0(p ImagePolicy, podSpec *corev1.PodSpec, opts options) CheckResult {
+	disallowedTags := sets.New(p.DisallowedTags...)
+	badTags := sets.New[string]()
+	containersWithBadRegistry := NewViolations(opts.withFieldErrors)
+	containersWithBadTag := NewViolations(opts.withFieldErrors)
+	containersWithoutDigest := NewViolations(opts.withFieldErrors)
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, path *field.Path) {
+		// report a single field error per container, with the first violation
+		errs := []*field.Error{withBadValue(forbidden(path.Child("image")), container.Image)}
+		add := func(v *Violations) {
+			v.Add(container.Name, errs...)
+			errs = nil
+		}
+
+		repository, tag, digest := parseImage(container.Image)
+		if len(p.AllowedRegistries) > 0 && !hasAnyPathPrefix(repository, p.AllowedRegistries) {
+			add(&containersWithBadRegistry)
+		}
+		if tag == "" && digest == "" {
+			tag = "latest"
+		}
+		if tag != "" && disallowedTags.Has(tag) {
+			badTags.Insert(tag)
+			add(&containersWithBadTag)
+		}
+		if p.RequireDigest && digest == "" {
+			add(&containersWithoutDigest)
+		}
+	})
+
+	var forbiddenDetails []string
+	var errList *field.ErrorList
+	if opts.withFieldErrors {
+		errs := append(*containersWithBadRegistry.Errs(), *containersWithBadTag.Errs()...)
+		errs = append(errs, *containersWithoutDigest.Errs()...)
+		errList = &errs
+	}
+	if !containersWithBadRegistry.Empty() {
+		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
+			`%s %s must use images from %s %s`,
+			pluralize("container", "containers", containersWithBadRegistry.Len()),
+			joinQuote(containersWithBadRegistry.Data()),
+			pluralize("registry", "registries", len(p.AllowedRegistries)),
+			joinQuote(p.AllowedRegistries)))
+	}
+	if !containersWithBadTag.Empty() {
+		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
+			`%s %s must not use image %s %s`,
+			pluralize("container", "containers", containersWithBadTag.Len()),
+			joinQuote(containersWithBadTag.Data()),
+			pluralize("tag", "tags", badTags.Len()),
+			joinQuote(sets.List(badTags))))
+	}
+	if !containersWithoutDigest.Empty() {
+		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
+			`%s %s must reference images by digest`,
+			pluralize("container", "containers", containersWithoutDigest.Len()),
+			joinQuote(containersWithoutDigest.Data())))
+	}
+	if len(forbiddenDetails) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "disallowed images",
+			ForbiddenDetail: strings.Join(forbiddenDetails, "; "),
+			ErrList:         errList,
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// parseImage splits an image reference into its normalized repository, its tag and its digest.
+func parseImage(image string) (repository, tag, digest string) {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	// a colon before the last slash separates the registry host from its port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return normalizeRepository(name), tag, digest
+}
+
+// normalizeRepository adds the implicit Docker Hub registry and library namespace to a repository.
+func normalizeRepository(name string) string {
+	i := strings.Index(name, "/")
+	if i < 0 {
+		return "docker.io/library/" + name
+	}
+	if host := name[:i]; !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return "docker.io/" + name
+	}
+	return name
+}
+
+// hasAnyPathPrefix returns whether the repository is one of the prefixes, or is within one of them.
+// Prefixes match whole path components, so "registry.example.com" does not match "registry.example.com.evil.io/app".
+func hasAnyPathPrefix(repository string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if repository == prefix || strings.HasPrefix(repository, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+func TestImages(t *testing.T) {
+	const digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		name          string
+		policy        ImagePolicy
+		pod           *corev1.Pod
+		opts          options
+		expectAllowed bool
+		expectDetail  string
+		expectErrList field.ErrorList
+	}{
+		{
+			name:   "allowed registries",
+			policy: ImagePolicy{AllowedRegistries: []string{"registry.example.com/", "docker.io/library/"}},
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Image: "registry.example.com/app:1.0"},
+					{Name: "b", Image: "nginx:1.25"},
+					{Name: "c", Image: "registry.example.com:5000/app:1.0"},
+					{Name: "d", Image: "quay.io/app:1.0"},
+					{Name: "e", Image: "example/app:1.0"},
+				},
+			}},
+			expectDetail: `containers "c", "d", "e" must use images from registries "registry.example.com/", "docker.io/library/"`,
+		},
+		{
+			name:   "allowed registries without trailing slash",
+			policy: ImagePolicy{AllowedRegistries: []string{"registry.example.com", "docker.io/library"}},
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Image: "registry.example.com/app:1.0"},
+					{Name: "b", Image: "nginx:1.25"},
+					{Name: "c", Image: "registry.example.com.evil.io/app:1.0"},
+					{Name: "d", Image: "registry.example.community/app:1.0"},
+					{Name: "e", Image: "docker.io/library-evil/app:1.0"},
+				},
+			}},
+			expectDetail: `containers "c", "d", "e" must use images from registries "registry.example.com", "docker.io/library"`,
+		},
+		{
+			name:   "disallowed tags",
+			policy: ImagePolicy{DisallowedTags: []string{"latest", "dev"}},
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Image: "nginx:1.25"},
+					{Name: "b", Image: "nginx@" + digest},
+					{Name: "c", Image: "nginx"},
+					{Name: "d", Image: "localhost:5000/app:dev"},
+					{Name: "e", Image: "nginx:latest"},
+				},
+			}},
+			expectDetail: `containers "c", "d", "e" must not use image tags "dev", "latest"`,
+		},
+		{
+			name:   "require digest",
+			policy: ImagePolicy{RequireDigest: true},
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Image: "nginx@" + digest},
+					{Name: "b", Image: "nginx:1.25@" + digest},
+					{Name: "c", Image: "nginx:1.25"},
+				},
+			}},
+			expectDetail: `container "c" must reference images by digest`,
+		},
+		{
+			name:          "allowed",
+			policy:        ImagePolicy{AllowedRegistries: []string{"registry.example.com/"}, DisallowedTags: []string{"latest"}, RequireDigest: true},
+			pod:           &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "a", Image: "registry.example.com/app:1.0@" + digest}}}},
+			expectAllowed: true,
+		},
+		{
+			name:   "all container types, enable field error list",
+			policy: ImagePolicy{AllowedRegistries: []string{"registry.example.com/"}, DisallowedTags: []string{"latest"}, RequireDigest: true},
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "a", Image: "registry.example.com/app@" + digest},
+					{Name: "b", Image: "nginx"},
+				},
+				Containers: []corev1.Container{
+					{Name: "c", Image: "registry.example.com/app:latest"},
+				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "d", Image: "registry.example.com/debug:1.0"}},
+				},
+			}},
+			opts: options{
+				withFieldErrors: true,
+			},
+			expectDetail: `container "b" must use images from registry "registry.example.com/"; ` +
+				`containers "b", "c" must not use image tag "latest"; ` +
+				`containers "b", "c", "d" must reference images by digest`,
+			expectErrList: field.ErrorList{
+				{Type: field.ErrorTypeForbidden, Field: "spec.initContainers[1].image", BadValue: "nginx"},
+				{Type: field.ErrorTypeForbidden, Field: "spec.containers[0].image", BadValue: "registry.example.com/app:latest"},
+				{Type: field.ErrorTypeForbidden, Field: "spec.ephemeralContainers[0].image", BadValue: "registry.example.com/debug:1.0"},
+			},
+		},
+	}
+
+	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(field.Error{}, "Detail"), cmpopts.SortSlices(func(a, b *field.Error) bool { return a.Error() < b.Error() })}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := imagesV1Dot0(tc.policy, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.expectAllowed {
+				t.Fatalf("expected allowed=%v, got %v", tc.expectAllowed, result.Allowed)
+			}
+			if tc.expectAllowed {
+				return
+			}
+			if e, a := "disallowed images", result.ForbiddenReason; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if e, a := tc.expectDetail, result.ForbiddenDetail; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if result.ErrList != nil {
+				if diff := cmp.Diff(tc.expectErrList, *result.ErrList, cmpOpts...); diff != "" {
+					t.Errorf("unexpected field errors (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWithChecks(t *testing.T) {
+	evaluator, err := NewEvaluator(DefaultChecks(), nil, WithChecks(CheckImages(ImagePolicy{
+		Level:          api.LevelBaseline,
+		DisallowedTags: []string{"latest"},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "a", Image: "nginx"}}}}
+	for _, level := range []api.Level{api.LevelBaseline, api.LevelRestricted} {
+		results := evaluator.EvaluatePod(api.LevelVersion{Level: level, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec)
+		found := false
+		for _, result := range results {
+			found = found || result.ForbiddenReason == "disallowed images"
+		}
+		if !found {
+			t.Errorf("%s: expected the image check to be evaluated, got %v", level, results)
+		}
+	}
+
+	if _, err := NewEvaluator(DefaultChecks(), nil, WithChecks(CheckPrivileged())); err == nil {
+		t.Error("expected an error for a duplicate check")
+	}
+}
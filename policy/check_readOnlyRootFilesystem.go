@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Containers must run with a read-only root filesystem.
+This check is only evaluated if HardenedChecks are added to the Evaluator.
+
+**Restricted Fields:**
+spec.containers[*].securityContext.readOnlyRootFilesystem
+spec.initContainers[*].securityContext.readOnlyRootFilesystem
+spec.ephemeralContainers[*].securityContext.readOnlyRootFilesystem
+
+**Allowed Values:**
+true
+*/
+
+func init() {
+	addHardenedCheck(CheckReadOnlyRootFilesystem)
+}
+
+// CheckReadOnlyRootFilesystem returns a restricted level check
+// that requires readOnlyRootFilesystem=true in 1.0+
+func CheckReadOnlyRootFilesystem() Check {
+	return Check{
+		ID:    "readOnlyRootFilesystem",
+		Level: api.LevelRestricted,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod:       withOptions(readOnlyRootFilesystemV1Dot0),
+			},
+			// Starting 1.25, windows pods would be exempted from this check using pod.spec.os field when set to windows.
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 25),
+				CheckPod:       withOptions(readOnlyRootFilesystemV1Dot25),
+			},
+		},
+	}
+}
+
+func readOnlyRootFilesystemV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	badContainers := NewViolations(opts.withFieldErrors)
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, path *field.Path) {
+		if container.SecurityContext == nil || container.SecurityContext.ReadOnlyRootFilesystem == nil {
+			badContainers.Add(container.Name, required(path.Child("securityContext", "readOnlyRootFilesystem")))
+		} else if !*container.SecurityContext.ReadOnlyRootFilesystem {
+			badContainers.Add(container.Name, withBadValue(forbidden(path.Child("securityContext", "readOnlyRootFilesystem")), false))
+		}
+	})
+
+	if !badContainers.Empty() {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "writable root filesystem",
+			ForbiddenDetail: fmt.Sprintf(
+				`%s %s must set securityContext.readOnlyRootFilesystem=true`,
+				pluralize("container", "containers", badContainers.Len()),
+				joinQuote(badContainers.Data()),
+			),
+			ErrList: badContainers.Errs(),
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+func readOnlyRootFilesystemV1Dot25(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	// Pod API validation would have failed if podOS == Windows and if readOnlyRootFilesystem has been set.
+	// We can admit the Windows pod even if readOnlyRootFilesystem has not been set.
+	if podSpec.OS != nil && podSpec.OS.Name == corev1.Windows {
+		return CheckResult{Allowed: true}
+	}
+	return readOnlyRootFilesystemV1Dot0(podMetadata, podSpec, opts)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestReadOnlyRootFilesystem_1_25(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		opts          options
+		expectReason  string
+		expectDetail  string
+		allowed       bool
+		expectErrList field.ErrorList
+	}{
+		{
+			name: "multiple containers",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a"},
+					{Name: "b", SecurityContext: &corev1.SecurityContext{}},
+					{Name: "c", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(false)}},
+					{Name: "d", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)}},
+				}}},
+			expectReason: `writable root filesystem`,
+			expectDetail: `containers "a", "b", "c" must set securityContext.readOnlyRootFilesystem=true`,
+		},
+		{
+			name: "all container types, enable field error list",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "a", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(false)}},
+				},
+				Containers: []corev1.Container{
+					{Name: "b", SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)}},
+				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "c"}},
+				}}},
+			opts: options{
+				withFieldErrors: true,
+			},
+			expectReason: `writable root filesystem`,
+			expectDetail: `containers "a", "c" must set securityContext.readOnlyRootFilesystem=true`,
+			expectErrList: field.ErrorList{
+				{Type: field.ErrorTypeForbidden, Field: "spec.initContainers[0].securityContext.readOnlyRootFilesystem", BadValue: false},
+				{Type: field.ErrorTypeRequired, Field: "spec.ephemeralContainers[0].securityContext.readOnlyRootFilesystem", BadValue: ""},
+			},
+		},
+		{
+			name: "windows pod, admit without checking readOnlyRootFilesystem",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				OS: &corev1.PodOS{Name: corev1.Windows},
+				Containers: []corev1.Container{
+					{Name: "a"},
+				}}},
+			allowed: true,
+		},
+		{
+			name: "linux pod, reject if security context is not set",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				OS: &corev1.PodOS{Name: corev1.Linux},
+				Containers: []corev1.Container{
+					{Name: "a"},
+				}}},
+			expectReason: `writable root filesystem`,
+			expectDetail: `container "a" must set securityContext.readOnlyRootFilesystem=true`,
+		},
+	}
+
+	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(field.Error{}, "Detail"), cmpopts.SortSlices(func(a, b *field.Error) bool { return a.Error() < b.Error() })}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := readOnlyRootFilesystemV1Dot25(&tc.pod.ObjectMeta, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.allowed {
+				t.Fatalf("expected allowed=%v, got %v", tc.allowed, result.Allowed)
+			}
+			if e, a := tc.expectReason, result.ForbiddenReason; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if e, a := tc.expectDetail, result.ForbiddenDetail; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if result.ErrList != nil {
+				if diff := cmp.Diff(tc.expectErrList, *result.ErrList, cmpOpts...); diff != "" {
+					t.Errorf("unexpected field errors (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestReadOnlyRootFilesystem_1_0(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		OS: &corev1.PodOS{Name: corev1.Windows},
+		Containers: []corev1.Container{
+			{Name: "a"},
+		}}}
+	result := readOnlyRootFilesystemV1Dot0(&pod.ObjectMeta, &pod.Spec, options{})
+	if result.Allowed {
+		t.Fatal("expected windows pods to be checked before 1.25")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+)
+
+/*
+Containers must declare CPU and memory requests and limits, within the configured bounds.
+This check is not part of any level unless it is added to the Evaluator.
+Ephemeral containers are not checked, since they cannot declare resources.
+
+Restricted Fields:
+spec.containers[*].resources.requests.cpu
+spec.containers[*].resources.requests.memory
+spec.containers[*].resources.limits.cpu
+spec.containers[*].resources.limits.memory
+spec.initContainers[*].resources.requests.cpu
+spec.initContainers[*].resources.requests.memory
+spec.initContainers[*].resources.limits.cpu
+spec.initContainers[*].resources.limits.memory
+
+Allowed Values: quantities between the configured minimum and maximum
+*/
+
+// checkedResources are the resources containers must declare requests and limits for.
+var checkedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// ResourcePolicy configures the check returned by CheckResources.
+type ResourcePolicy struct {
+	// Level is the policy level the check belongs to. Must be Baseline or Restricted.
+	Level api.Level
+	// Minimum optionally bounds the CPU and memory requests and limits from below.
+	Minimum corev1.ResourceList
+	// Maximum optionally bounds the CPU and memory requests and limits from above.
+	Maximum corev1.ResourceList
+}
+
+// CheckResources returns a check that requires CPU and memory requests and limits in 1.0+.
+// The check has the ID "resources".
+func CheckResources(p ResourcePolicy) Check {
+	p.Minimum = p.Minimum.DeepCopy()
+	p.Maximum = p.Maximum.DeepCopy()
+	return Check{
+		ID:    "resources",
+		Level: p.Level,
+		Versions: []VersionedCheck{
+			{
+				MinimumVersion: api.MajorMinorVersion(1, 0),
+				CheckPod: withOptions(func(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+					return resourcesV1Dot0(p, podSpec, opts)
+				}),
+			},
+		},
+	}
+}
+
+func resourcesV1Dot0(p ResourcePolicy, podSpec *corev1.PodSpec, opts options) CheckResult {
+	// container names are unique across all container types
+	ephemeralContainers := sets.New[string]()
+	for _, c := range podSpec.EphemeralContainers {
+		ephemeralContainers.Insert(c.Name)
+	}
+
+	missingFields := sets.New[string]()
+	outOfBounds := sets.New[string]()
+	containersMissingResources := NewViolations(opts.withFieldErrors)
+	containersOutOfBounds := NewViolations(opts.withFieldErrors)
+
+	visitContainers(podSpec, opts, func(container *corev1.Container, path *field.Path) {
+		if ephemeralContainers.Has(container.Name) {
+			return
+		}
+
+		var missingErrs, outOfBoundsErrs []*field.Error
+		missing, bad := false, false
+		for _, kind := range []string{"requests", "limits"} {
+			list := container.Resources.Requests
+			if kind == "limits" {
+				list = container.Resources.Limits
+			}
+			for _, name := range checkedResources {
+				quantityPath := path.Child("resources", kind, string(name))
+				quantity, ok := list[name]
+				if !ok {
+					missing = true
+					missingFields.Insert(fmt.Sprintf("resources.%s.%s", kind, name))
+					missingErrs = append(missingErrs, required(quantityPath))
+					continue
+				}
+				if min, ok := p.Minimum[name]; ok && quantity.Cmp(min) < 0 {
+					bad = true
+					outOfBounds.Insert(fmt.Sprintf("resources.%s.%s >= %s", kind, name, min.String()))
+					outOfBoundsErrs = append(outOfBoundsErrs, withBadValue(forbidden(quantityPath), quantity.String()))
+				}
+				if max, ok := p.Maximum[name]; ok && quantity.Cmp(max) > 0 {
+					bad = true
+					outOfBounds.Insert(fmt.Sprintf("resources.%s.%s <= %s", kind, name, max.String()))
+					outOfBoundsErrs = append(outOfBoundsErrs, withBadValue(forbidden(quantityPath), quantity.String()))
+				}
+			}
+		}
+		if missing {
+			containersMissingResources.Add(container.Name, missingErrs...)
+		}
+		if bad {
+			containersOutOfBounds.Add(container.Name, outOfBoundsErrs...)
+		}
+	})
+
+	var forbiddenDetails []string
+	var errList *field.ErrorList
+	if opts.withFieldErrors {
+		errs := append(*containersMissingResources.Errs(), *containersOutOfBounds.Errs()...)
+		errList = &errs
+	}
+	if !containersMissingResources.Empty() {
+		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
+			`%s %s must set %s`,
+			pluralize("container", "containers", containersMissingResources.Len()),
+			joinQuote(containersMissingResources.Data()),
+			strings.Join(sortedResourceFields(missingFields), ", ")))
+	}
+	if !containersOutOfBounds.Empty() {
+		forbiddenDetails = append(forbiddenDetails, fmt.Sprintf(
+			`%s %s must set %s`,
+			pluralize("container", "containers", containersOutOfBounds.Len()),
+			joinQuote(containersOutOfBounds.Data()),
+			strings.Join(sortedResourceFields(outOfBounds), ", ")))
+	}
+	if len(forbiddenDetails) > 0 {
+		return CheckResult{
+			Allowed:         false,
+			ForbiddenReason: "unbounded resources",
+			ForbiddenDetail: strings.Join(forbiddenDetails, "; "),
+			ErrList:         errList,
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// sortedResourceFields sorts resource fields with requests before limits.
+func sortedResourceFields(fields sets.Set[string]) []string {
+	var requests, limits []string
+	for _, f := range sets.List(fields) {
+		if strings.HasPrefix(f, "resources.requests.") {
+			requests = append(requests, f)
+		} else {
+			limits = append(limits, f)
+		}
+	}
+	return append(requests, limits...)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestResources(t *testing.T) {
+	resources := func(requestsCPU, requestsMemory, limitsCPU, limitsMemory string) corev1.ResourceRequirements {
+		list := func(cpu, memory string) corev1.ResourceList {
+			l := corev1.ResourceList{}
+			if cpu != "" {
+				l[corev1.ResourceCPU] = resource.MustParse(cpu)
+			}
+			if memory != "" {
+				l[corev1.ResourceMemory] = resource.MustParse(memory)
+			}
+			return l
+		}
+		return corev1.ResourceRequirements{Requests: list(requestsCPU, requestsMemory), Limits: list(limitsCPU, limitsMemory)}
+	}
+	bounded := ResourcePolicy{
+		Minimum: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+		Maximum: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+
+	tests := []struct {
+		name          string
+		policy        ResourcePolicy
+		pod           *corev1.Pod
+		opts          options
+		expectAllowed bool
+		expectDetail  string
+		expectErrList field.ErrorList
+	}{
+		{
+			name: "missing resources",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Resources: resources("100m", "128Mi", "1", "256Mi")},
+					{Name: "b"},
+					{Name: "c", Resources: resources("100m", "128Mi", "", "256Mi")},
+				},
+			}},
+			expectDetail: `containers "b", "c" must set resources.requests.cpu, resources.requests.memory, resources.limits.cpu, resources.limits.memory`,
+		},
+		{
+			name:   "out of bounds",
+			policy: bounded,
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Resources: resources("10m", "128Mi", "1", "1Gi")},
+					{Name: "b", Resources: resources("1m", "128Mi", "1", "2Gi")},
+				},
+			}},
+			expectDetail: `container "b" must set resources.requests.cpu >= 10m, resources.limits.memory <= 1Gi`,
+		},
+		{
+			name:   "ephemeral containers are not checked",
+			policy: bounded,
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "a", Resources: resources("100m", "128Mi", "1", "256Mi")},
+				},
+				EphemeralContainers: []corev1.EphemeralContainer{
+					{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug"}},
+				},
+			}},
+			expectAllowed: true,
+		},
+		{
+			name:   "all container types, enable field error list",
+			policy: bounded,
+			pod: &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "a", Resources: resources("100m", "", "1", "256Mi")},
+				},
+				Containers: []corev1.Container{
+					{Name: "b", Resources: resources("100m", "128Mi", "", "2Gi")},
+				},
+			}},
+			opts: options{
+				withFieldErrors: true,
+			},
+			expectDetail: `containers "a", "b" must set resources.requests.memory, resources.limits.cpu; ` +
+				`container "b" must set resources.limits.memory <= 1Gi`,
+			expectErrList: field.ErrorList{
+				{Type: field.ErrorTypeRequired, Field: "spec.initContainers[0].resources.requests.memory", BadValue: ""},
+				{Type: field.ErrorTypeRequired, Field: "spec.containers[0].resources.limits.cpu", BadValue: ""},
+				{Type: field.ErrorTypeForbidden, Field: "spec.containers[0].resources.limits.memory", BadValue: "2Gi"},
+			},
+		},
+	}
+
+	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(field.Error{}, "Detail"), cmpopts.SortSlices(func(a, b *field.Error) bool { return a.Error() < b.Error() })}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := resourcesV1Dot0(tc.policy, &tc.pod.Spec, tc.opts)
+			if result.Allowed != tc.expectAllowed {
+				t.Fatalf("expected allowed=%v, got %v", tc.expectAllowed, result.Allowed)
+			}
+			if tc.expectAllowed {
+				return
+			}
+			if e, a := "unbounded resources", result.ForbiddenReason; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if e, a := tc.expectDetail, result.ForbiddenDetail; e != a {
+				t.Errorf("expected\n%s\ngot\n%s", e, a)
+			}
+			if result.ErrList != nil {
+				if diff := cmp.Diff(tc.expectErrList, *result.ErrList, cmpOpts...); diff != "" {
+					t.Errorf("unexpected field errors (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -22,7 +22,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/api"
 )
 
@@ -86,143 +85,66 @@ func CheckRestrictedVolumes() Check {
 	}
 }
 
+var (
+	restrictedVolumesAllowedV1Dot0 = sets.New(
+		"configMap",
+		"csi",
+		"downwardAPI",
+		"emptyDir",
+		"ephemeral",
+		"image",
+		"persistentVolumeClaim",
+		"projected",
+		"secret",
+	)
+
+	// volumeTypes are the names of the known volume types.
+	volumeTypes = restrictedVolumesAllowedV1Dot0.Union(sets.New(
+		"hostPath",
+		"gcePersistentDisk",
+		"awsElasticBlockStore",
+		"gitRepo",
+		"nfs",
+		"iscsi",
+		"glusterfs",
+		"rbd",
+		"flexVolume",
+		"cinder",
+		"cephfs",
+		"flocker",
+		"fc",
+		"azureFile",
+		"vsphereVolume",
+		"quobyte",
+		"azureDisk",
+		"photonPersistentDisk",
+		"portworxVolume",
+		"scaleIO",
+		"storageos",
+	))
+)
+
+// VolumeTypes returns the sorted names of the volume types known to the restrictedVolumes check,
+// as used in allow-list overrides.
+func VolumeTypes() []string {
+	return sets.List(volumeTypes)
+}
+
 func restrictedVolumesV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
+	allowedVolumeTypes := opts.allowedVolumeTypes(restrictedVolumesAllowedV1Dot0)
 	badVolumes := NewViolations(opts.withFieldErrors)
 	badVolumeTypes := sets.NewString()
 
 	for i, volume := range podSpec.Volumes {
-		switch {
-		case volume.ConfigMap != nil,
-			volume.CSI != nil,
-			volume.DownwardAPI != nil,
-			volume.EmptyDir != nil,
-			volume.Ephemeral != nil,
-			volume.Image != nil,
-			volume.PersistentVolumeClaim != nil,
-			volume.Projected != nil,
-			volume.Secret != nil:
+		volumeType := volumeTypeOf(&volume)
+		if allowedVolumeTypes.Has(volumeType) {
 			continue
-
-		default:
-			var volumesIndexPath *field.Path
-			if opts.withFieldErrors {
-				volumesIndexPath = volumesPath.Index(i)
-			} else {
-				badVolumes.Add(volume.Name)
-			}
-
-			switch {
-			case volume.HostPath != nil:
-				badVolumeTypes.Insert("hostPath")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("hostPath")))
-				}
-			case volume.GCEPersistentDisk != nil:
-				badVolumeTypes.Insert("gcePersistentDisk")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("gcePersistentDisk")))
-				}
-			case volume.AWSElasticBlockStore != nil:
-				badVolumeTypes.Insert("awsElasticBlockStore")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("awsElasticBlockStore")))
-				}
-			case volume.GitRepo != nil:
-				badVolumeTypes.Insert("gitRepo")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("gitRepo")))
-				}
-			case volume.NFS != nil:
-				badVolumeTypes.Insert("nfs")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("nfs")))
-				}
-			case volume.ISCSI != nil:
-				badVolumeTypes.Insert("iscsi")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("iscsi")))
-				}
-			case volume.Glusterfs != nil:
-				badVolumeTypes.Insert("glusterfs")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("glusterfs")))
-				}
-			case volume.RBD != nil:
-				badVolumeTypes.Insert("rbd")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("rbd")))
-				}
-			case volume.FlexVolume != nil:
-				badVolumeTypes.Insert("flexVolume")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("flexVolume")))
-				}
-			case volume.Cinder != nil:
-				badVolumeTypes.Insert("cinder")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("cinder")))
-				}
-			case volume.CephFS != nil:
-				badVolumeTypes.Insert("cephfs")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("cephfs")))
-				}
-			case volume.Flocker != nil:
-				badVolumeTypes.Insert("flocker")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("flocker")))
-				}
-			case volume.FC != nil:
-				badVolumeTypes.Insert("fc")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("fc")))
-				}
-			case volume.AzureFile != nil:
-				badVolumeTypes.Insert("azureFile")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("azureFile")))
-				}
-			case volume.VsphereVolume != nil:
-				badVolumeTypes.Insert("vsphereVolume")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("vsphereVolume")))
-				}
-			case volume.Quobyte != nil:
-				badVolumeTypes.Insert("quobyte")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("quobyte")))
-				}
-			case volume.AzureDisk != nil:
-				badVolumeTypes.Insert("azureDisk")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("azureDisk")))
-				}
-			case volume.PhotonPersistentDisk != nil:
-				badVolumeTypes.Insert("photonPersistentDisk")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("photonPersistentDisk")))
-				}
-			case volume.PortworxVolume != nil:
-				badVolumeTypes.Insert("portworxVolume")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("portworxVolume")))
-				}
-			case volume.ScaleIO != nil:
-				badVolumeTypes.Insert("scaleIO")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("scaleIO")))
-				}
-			case volume.StorageOS != nil:
-				badVolumeTypes.Insert("storageos")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("storageos")))
-				}
-			default:
-				badVolumeTypes.Insert("unknown")
-				if opts.withFieldErrors {
-					badVolumes.Add(volume.Name, forbidden(volumesIndexPath.Child("unknown")))
-				}
-			}
+		}
+		badVolumeTypes.Insert(volumeType)
+		if opts.withFieldErrors {
+			badVolumes.Add(volume.Name, forbidden(volumesPath.Index(i).Child(volumeType)))
+		} else {
+			badVolumes.Add(volume.Name)
 		}
 	}
 
@@ -244,3 +166,71 @@ func restrictedVolumesV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.Pod
 
 	return CheckResult{Allowed: true}
 }
+
+// volumeTypeOf returns the name of the volume source field set by the volume, or "unknown".
+func volumeTypeOf(volume *corev1.Volume) string {
+	switch {
+	case volume.ConfigMap != nil:
+		return "configMap"
+	case volume.CSI != nil:
+		return "csi"
+	case volume.DownwardAPI != nil:
+		return "downwardAPI"
+	case volume.EmptyDir != nil:
+		return "emptyDir"
+	case volume.Ephemeral != nil:
+		return "ephemeral"
+	case volume.Image != nil:
+		return "image"
+	case volume.PersistentVolumeClaim != nil:
+		return "persistentVolumeClaim"
+	case volume.Projected != nil:
+		return "projected"
+	case volume.Secret != nil:
+		return "secret"
+	case volume.HostPath != nil:
+		return "hostPath"
+	case volume.GCEPersistentDisk != nil:
+		return "gcePersistentDisk"
+	case volume.AWSElasticBlockStore != nil:
+		return "awsElasticBlockStore"
+	case volume.GitRepo != nil:
+		return "gitRepo"
+	case volume.NFS != nil:
+		return "nfs"
+	case volume.ISCSI != nil:
+		return "iscsi"
+	case volume.Glusterfs != nil:
+		return "glusterfs"
+	case volume.RBD != nil:
+		return "rbd"
+	case volume.FlexVolume != nil:
+		return "flexVolume"
+	case volume.Cinder != nil:
+		return "cinder"
+	case volume.CephFS != nil:
+		return "cephfs"
+	case volume.Flocker != nil:
+		return "flocker"
+	case volume.FC != nil:
+		return "fc"
+	case volume.AzureFile != nil:
+		return "azureFile"
+	case volume.VsphereVolume != nil:
+		return "vsphereVolume"
+	case volume.Quobyte != nil:
+		return "quobyte"
+	case volume.AzureDisk != nil:
+		return "azureDisk"
+	case volume.PhotonPersistentDisk != nil:
+		return "photonPersistentDisk"
+	case volume.PortworxVolume != nil:
+		return "portworxVolume"
+	case volume.ScaleIO != nil:
+		return "scaleIO"
+	case volume.StorageOS != nil:
+		return "storageos"
+	default:
+		return "unknown"
+	}
+}
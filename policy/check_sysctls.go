@@ -106,19 +106,19 @@ var (
 )
 
 func sysctlsV1Dot0(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
-	return sysctls(podMetadata, podSpec, sysctlsAllowedV1Dot0, opts)
+	return sysctls(podMetadata, podSpec, opts.allowedSysctls(sysctlsAllowedV1Dot0), opts)
 }
 
 func sysctlsV1Dot27(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
-	return sysctls(podMetadata, podSpec, sysctlsAllowedV1Dot27, opts)
+	return sysctls(podMetadata, podSpec, opts.allowedSysctls(sysctlsAllowedV1Dot27), opts)
 }
 
 func sysctlsV1Dot29(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
-	return sysctls(podMetadata, podSpec, sysctlsAllowedV1Dot29, opts)
+	return sysctls(podMetadata, podSpec, opts.allowedSysctls(sysctlsAllowedV1Dot29), opts)
 }
 
 func sysctlsV1Dot32(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts options) CheckResult {
-	return sysctls(podMetadata, podSpec, sysctlsAllowedV1Dot32, opts)
+	return sysctls(podMetadata, podSpec, opts.allowedSysctls(sysctlsAllowedV1Dot32), opts)
 }
 
 func sysctls(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, sysctlsAllowedSet sets.Set[string], opts options) CheckResult {
@@ -17,6 +17,8 @@ limitations under the License.
 package policy
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -84,6 +86,16 @@ type CheckResult struct {
 	// ErrList should only be set if Allowed is false, and is optional.
 	// ErrList is a detailed list of restricted field errors.
 	ErrList *field.ErrorList
+
+	// CheckID is the ID of the check that produced the result.
+	// It is set by the Evaluator, and does not need to be set by a CheckPodFn.
+	CheckID CheckID
+	// Level is the level of the check that produced the result.
+	// It is set by the Evaluator, and does not need to be set by a CheckPodFn.
+	Level api.Level
+	// MinimumVersion is the MinimumVersion of the VersionedCheck that produced the result.
+	// It is set by the Evaluator, and does not need to be set by a CheckPodFn.
+	MinimumVersion api.Version
 }
 
 // AggergateCheckResult holds the aggregate result of running CheckPod across multiple checks.
@@ -97,7 +109,12 @@ type AggregateCheckResult struct {
 	// ForbiddenReasons and ForbiddenDetails must have the same number of elements, and the indexes are for the same check.
 	ForbiddenDetails []string
 	// ErrLists is a slice of the field errors from all the forbidden checks.
+	// ErrLists is keyed by forbidden reason, so checks sharing a reason share an entry.
+	// Use ForbiddenResults to attribute field errors to individual checks.
 	ErrLists map[string]field.ErrorList
+	// ForbiddenResults is a slice of the results of all the forbidden checks.
+	// ForbiddenResults has the same number of elements as ForbiddenReasons, and the indexes are for the same check.
+	ForbiddenResults []CheckResult
 }
 
 // ForbiddenReason returns a comma-separated string of the forbidden reasons.
@@ -125,6 +142,21 @@ func (a *AggregateCheckResult) ForbiddenDetail() string {
 	return b.String()
 }
 
+// FieldErrorBody formats a field error of a forbidden check like field.Error.ErrorBody(), followed by the
+// bad value for forbidden fields when set, which ErrorBody omits.
+// Example: Forbidden: ["NET_RAW"]
+func FieldErrorBody(err *field.Error) string {
+	body := err.ErrorBody()
+	if err.Type != field.ErrorTypeForbidden || err.BadValue == nil || err.BadValue == "" {
+		return body
+	}
+	value, marshalErr := json.Marshal(err.BadValue)
+	if marshalErr != nil {
+		return fmt.Sprintf("%s: %v", body, err.BadValue)
+	}
+	return fmt.Sprintf("%s: %s", body, value)
+}
+
 // UnknownForbiddenReason is used as the placeholder forbidden reason for checks that incorrectly disallow without providing a reason.
 const UnknownForbiddenReason = "unknown forbidden reason"
 
@@ -132,12 +164,14 @@ const UnknownForbiddenReason = "unknown forbidden reason"
 // The aggregated reason is a comma-separated
 func AggregateCheckResults(results []CheckResult) AggregateCheckResult {
 	var (
-		reasons  []string
-		details  []string
-		errLists = make(map[string]field.ErrorList)
+		reasons   []string
+		details   []string
+		errLists  = make(map[string]field.ErrorList)
+		forbidden []CheckResult
 	)
 	for _, result := range results {
 		if !result.Allowed {
+			forbidden = append(forbidden, result)
 			if len(result.ForbiddenReason) == 0 {
 				reasons = append(reasons, UnknownForbiddenReason)
 				if result.ErrList != nil {
@@ -157,12 +191,14 @@ func AggregateCheckResults(results []CheckResult) AggregateCheckResult {
 		ForbiddenReasons: reasons,
 		ForbiddenDetails: details,
 		ErrLists:         errLists,
+		ForbiddenResults: forbidden,
 	}
 }
 
 var (
 	defaultChecks      []func() Check
 	experimentalChecks []func() Check
+	hardenedChecks     []func() Check
 )
 
 func addCheck(f func() Check) {
@@ -175,8 +211,12 @@ func addCheck(f func() Check) {
 	}
 }
 
+func addHardenedCheck(f func() Check) {
+	hardenedChecks = append(hardenedChecks, f)
+}
+
 // DefaultChecks returns checks that are expected to be enabled by default.
-// The results are mutually exclusive with ExperimentalChecks.
+// The results are mutually exclusive with ExperimentalChecks and HardenedChecks.
 // It returns a new copy of checks on each invocation and is expected to be called once at setup time.
 func DefaultChecks() []Check {
 	retval := make([]Check, 0, len(defaultChecks))
@@ -187,7 +227,7 @@ func DefaultChecks() []Check {
 }
 
 // ExperimentalChecks returns checks that have not yet been assigned to policy versions.
-// The results are mutually exclusive with DefaultChecks.
+// The results are mutually exclusive with DefaultChecks and HardenedChecks.
 // It returns a new copy of checks on each invocation and is expected to be called once at setup time.
 func ExperimentalChecks() []Check {
 	retval := make([]Check, 0, len(experimentalChecks))
@@ -196,3 +236,16 @@ func ExperimentalChecks() []Check {
 	}
 	return retval
 }
+
+// HardenedChecks returns opt-in restricted level checks that go beyond the Pod Security Standards,
+// such as requiring a read-only root filesystem.
+// The results are mutually exclusive with DefaultChecks and ExperimentalChecks, and are expected to be
+// appended to DefaultChecks when constructing an Evaluator.
+// It returns a new copy of checks on each invocation and is expected to be called once at setup time.
+func HardenedChecks() []Check {
+	retval := make([]Check, 0, len(hardenedChecks))
+	for _, f := range hardenedChecks {
+		retval = append(retval, f())
+	}
+	return retval
+}
@@ -20,11 +20,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // TestValidChecks ensures that all registered checks are valid.
 func TestValidChecks(t *testing.T) {
-	allChecks := append(DefaultChecks(), ExperimentalChecks()...)
+	allChecks := append(append(DefaultChecks(), ExperimentalChecks()...), HardenedChecks()...)
 
 	assert.NoError(t, validateChecks(allChecks))
 
@@ -41,3 +43,10 @@ func TestValidChecks(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldErrorBody(t *testing.T) {
+	path := field.NewPath("spec", "containers").Index(0).Child("securityContext", "capabilities", "add")
+	assert.Equal(t, `Forbidden: ["NET_RAW"]`, FieldErrorBody(withBadValue(field.Forbidden(path, ""), []string{"NET_RAW"})))
+	assert.Equal(t, `Forbidden: must not add capabilities`, FieldErrorBody(field.Forbidden(path, "must not add capabilities")))
+	assert.Equal(t, `Required value`, FieldErrorBody(field.Required(path, "")))
+}
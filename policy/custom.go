@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	customChecksLock sync.Mutex
+	customChecks     []func() Check
+)
+
+// RegisterCheck registers a custom check, to be evaluated alongside the built-in checks by evaluators
+// constructed from CustomChecks. It allows checks specific to a deployment, such as requiring images
+// from a trusted registry, to be added without modifying this package.
+//
+// The check is validated together with the built-in checks and the previously registered custom checks,
+// using the same rules as NewEvaluator:
+// 1. Check.ID must not be used by any other check, including experimental checks and the "images" and
+// "resources" checks returned by CheckImages and CheckResources
+// 2. Check.Level must be either Baseline or Restricted
+// 3. Check.Versions must be non-empty and assigned to policy versions, in a strictly increasing order
+// 4. Only restricted checks may set OverrideCheckIDs, and they may only override baseline checks
+// Every version must also set CheckPod.
+//
+// Like the built-in checks, custom checks are inflated to every policy version from their MinimumVersion,
+// and restricted custom checks replace the baseline checks they override at restricted level.
+func RegisterCheck(f func() Check) error {
+	c := f()
+	for _, v := range c.Versions {
+		if v.CheckPod == nil {
+			return fmt.Errorf("check %s: version %s has no CheckPod", c.ID, v.MinimumVersion)
+		}
+	}
+
+	customChecksLock.Lock()
+	defer customChecksLock.Unlock()
+
+	// Experimental checks are not assigned to policy versions yet, so their IDs are reserved without
+	// validating them, like the IDs of the optional checks added by NewEvaluator options.
+	reserved := []CheckID{CheckImages(ImagePolicy{}).ID, CheckResources(ResourcePolicy{}).ID}
+	for _, experimental := range ExperimentalChecks() {
+		reserved = append(reserved, experimental.ID)
+	}
+	for _, id := range reserved {
+		if c.ID == id {
+			return fmt.Errorf("check %s: ID is reserved", c.ID)
+		}
+	}
+
+	checks := append(DefaultChecks(), HardenedChecks()...)
+	for _, custom := range customChecks {
+		checks = append(checks, custom())
+	}
+	if err := validateChecks(append(checks, c)); err != nil {
+		return err
+	}
+	customChecks = append(customChecks, f)
+	return nil
+}
+
+// CustomChecks returns the checks registered with RegisterCheck, in registration order.
+// The results are mutually exclusive with DefaultChecks, ExperimentalChecks and HardenedChecks,
+// and are expected to be appended to DefaultChecks when constructing an Evaluator.
+// It returns a new copy of checks on each invocation and is expected to be called once at setup time.
+func CustomChecks() []Check {
+	customChecksLock.Lock()
+	defer customChecksLock.Unlock()
+
+	retval := make([]Check, 0, len(customChecks))
+	for _, f := range customChecks {
+		retval = append(retval, f())
+	}
+	return retval
+}
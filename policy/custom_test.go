@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+)
+
+func resetCustomChecks(t *testing.T) {
+	saved := customChecks
+	customChecks = nil
+	t.Cleanup(func() { customChecks = saved })
+}
+
+func TestRegisterCheck(t *testing.T) {
+	resetCustomChecks(t)
+
+	registered := func(c Check) func() Check { return func() Check { return c } }
+	require.NoError(t, RegisterCheck(registered(generateCheck("trustedRegistry", api.LevelBaseline, []string{"v1.0"}))))
+	require.NoError(t, RegisterCheck(registered(withOverrides(generateCheck("trustedRegistry_restricted", api.LevelRestricted, []string{"v1.20"}), []CheckID{"trustedRegistry", "hostPorts"}))))
+
+	customs := CustomChecks()
+	require.Len(t, customs, 2)
+	assert.Equal(t, CheckID("trustedRegistry"), customs[0].ID)
+	assert.Equal(t, CheckID("trustedRegistry_restricted"), customs[1].ID)
+
+	evaluator, err := NewEvaluator(append(DefaultChecks(), customs...), nil)
+	require.NoError(t, err)
+	checkIDs := func(lv api.LevelVersion) []CheckID {
+		var ids []CheckID
+		for _, r := range evaluator.EvaluatePod(lv, &metav1.ObjectMeta{}, &corev1.PodSpec{}) {
+			ids = append(ids, r.CheckID)
+		}
+		return ids
+	}
+	assert.Contains(t, checkIDs(api.LevelVersion{Level: api.LevelBaseline, Version: api.MajorMinorVersion(1, 0)}), CheckID("trustedRegistry"))
+	assert.Contains(t, checkIDs(api.LevelVersion{Level: api.LevelRestricted, Version: api.MajorMinorVersion(1, 19)}), CheckID("trustedRegistry"))
+	restrictedLatest := checkIDs(api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()})
+	assert.Contains(t, restrictedLatest, CheckID("trustedRegistry_restricted"))
+	assert.NotContains(t, restrictedLatest, CheckID("trustedRegistry"), "overridden at restricted")
+	assert.NotContains(t, restrictedLatest, CheckID("hostPorts"), "overridden at restricted")
+	assert.Contains(t, checkIDs(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}), CheckID("hostPorts"))
+}
+
+func TestRegisterCheckInvalid(t *testing.T) {
+	resetCustomChecks(t)
+	require.NoError(t, RegisterCheck(func() Check { return generateCheck("custom", api.LevelBaseline, []string{"v1.0"}) }))
+
+	noCheckPod := generateCheck("noCheckPod", api.LevelBaseline, []string{"v1.0"})
+	noCheckPod.Versions[0].CheckPod = nil
+
+	testcases := []struct {
+		name        string
+		check       Check
+		expectedErr string
+	}{{
+		name:        "built-in ID",
+		check:       generateCheck("privileged", api.LevelBaseline, []string{"v1.0"}),
+		expectedErr: "multiple checks registered for ID privileged",
+	}, {
+		name:        "registered ID",
+		check:       generateCheck("custom", api.LevelRestricted, []string{"v1.0"}),
+		expectedErr: "multiple checks registered for ID custom",
+	}, {
+		name:        "images ID",
+		check:       generateCheck("images", api.LevelBaseline, []string{"v1.0"}),
+		expectedErr: "check images: ID is reserved",
+	}, {
+		name:        "resources ID",
+		check:       generateCheck("resources", api.LevelBaseline, []string{"v1.0"}),
+		expectedErr: "check resources: ID is reserved",
+	}, {
+		name:        "invalid level",
+		check:       generateCheck("privilegedLevel", api.LevelPrivileged, []string{"v1.0"}),
+		expectedErr: "check privilegedLevel: invalid level privileged",
+	}, {
+		name:        "no versions",
+		check:       Check{ID: "empty", Level: api.LevelBaseline},
+		expectedErr: "check empty: empty",
+	}, {
+		name:        "unversioned",
+		check:       Check{ID: "unversioned", Level: api.LevelBaseline, Versions: []VersionedCheck{{CheckPod: withOptions(privilegedV1Dot0)}}},
+		expectedErr: "check unversioned: undefined version found",
+	}, {
+		name:        "no CheckPod",
+		check:       noCheckPod,
+		expectedErr: "check noCheckPod: version v1.0 has no CheckPod",
+	}, {
+		name:        "baseline override",
+		check:       withOverrides(generateCheck("baselineOverride", api.LevelBaseline, []string{"v1.0"}), []CheckID{"custom"}),
+		expectedErr: "check baselineOverride: only restricted checks may set overrides",
+	}, {
+		name:        "restricted override",
+		check:       withOverrides(generateCheck("restrictedOverride", api.LevelRestricted, []string{"v1.0"}), []CheckID{"runAsNonRoot"}),
+		expectedErr: "check restrictedOverride: overrides restricted check runAsNonRoot",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterCheck(func() Check { return tc.check })
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedErr, err.Error())
+		})
+	}
+	assert.Len(t, CustomChecks(), 1)
+}
+
+func TestRegisterCheckWithExperimentalChecks(t *testing.T) {
+	resetCustomChecks(t)
+	saved := experimentalChecks
+	t.Cleanup(func() { experimentalChecks = saved })
+	// Experimental checks are not assigned to policy versions.
+	experimentalChecks = append(experimentalChecks, func() Check {
+		return Check{ID: "experimental", Level: api.LevelBaseline}
+	})
+
+	require.NoError(t, RegisterCheck(func() Check { return generateCheck("custom", api.LevelBaseline, []string{"v1.0"}) }))
+	err := RegisterCheck(func() Check { return generateCheck("experimental", api.LevelBaseline, []string{"v1.0"}) })
+	require.Error(t, err)
+	assert.Equal(t, "check experimental: ID is reserved", err.Error())
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+)
+
+// Explainer is implemented by Evaluators that can trace the evaluation of a pod.
+type Explainer interface {
+	// ExplainPod evaluates the pod like EvaluatePod, and records how every check was selected and evaluated.
+	// Checks are always evaluated sequentially and to completion.
+	ExplainPod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) Explanation
+}
+
+// Explanation traces the evaluation of a pod against a policy level and version.
+type Explanation struct {
+	// Requested is the level and version the pod was evaluated against.
+	Requested api.LevelVersion
+	// Evaluated is the level and version the checks were selected for. Versions newer than the
+	// newest version known to the Evaluator, including "latest", are clamped to that version.
+	Evaluated api.LevelVersion
+	// Checks are the evaluated checks, in evaluation order.
+	Checks []ExplainedCheck
+	// Duration is the time taken to evaluate all the checks.
+	Duration time.Duration
+}
+
+// ExplainedCheck is the trace of a single evaluated check.
+type ExplainedCheck struct {
+	// Result is the result of the check. The CheckID, Level and MinimumVersion of the result identify
+	// the check and the revision selected for the evaluated version.
+	Result CheckResult
+	// Overrides are the IDs of the checks that were skipped in favor of this check at the evaluated
+	// level and version, e.g. hostPathVolumes for restrictedVolumes.
+	Overrides []CheckID
+	// Duration is the time taken to evaluate the check.
+	Duration time.Duration
+}
+
+// Results returns the results of the evaluated checks, as returned by EvaluatePod.
+func (e *Explanation) Results() []CheckResult {
+	var results []CheckResult
+	for _, c := range e.Checks {
+		results = append(results, c.Result)
+	}
+	return results
+}
+
+func (r *checkRegistry) ExplainPod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) Explanation {
+	explanation := Explanation{Requested: lv}
+	lv, checks, opts := r.checksFor(lv, opts)
+	explanation.Evaluated = lv
+
+	// overrides only apply to checks that exist at the evaluated version
+	baselineIDs := map[CheckID]bool{}
+	for _, c := range r.baselineChecks[lv.Version] {
+		baselineIDs[c.id] = true
+	}
+
+	start := time.Now()
+	for _, check := range checks {
+		checkStart := time.Now()
+		explained := ExplainedCheck{Result: check.evaluate(podMetadata, podSpec, opts)}
+		explained.Duration = time.Since(checkStart)
+		for _, id := range check.OverrideCheckIDs {
+			if baselineIDs[id] {
+				explained.Overrides = append(explained.Overrides, id)
+			}
+		}
+		explanation.Checks = append(explanation.Checks, explained)
+	}
+	explanation.Duration = time.Since(start)
+	return explanation
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/pod-security-admission/api"
+)
+
+func TestExplainPod(t *testing.T) {
+	evaluator, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(t, err)
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "a"}},
+		Volumes: []corev1.Volume{
+			{Name: "host", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}},
+		},
+	}}
+	find := func(e Explanation, id CheckID) *ExplainedCheck {
+		for i := range e.Checks {
+			if e.Checks[i].Result.CheckID == id {
+				return &e.Checks[i]
+			}
+		}
+		return nil
+	}
+
+	restricted := evaluator.ExplainPod(api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}, restricted.Requested)
+	assert.Equal(t, api.LevelVersion{Level: api.LevelRestricted, Version: evaluator.maxVersion}, restricted.Evaluated)
+	assert.Nil(t, find(restricted, "hostPathVolumes"), "overridden check should not be evaluated")
+	volumes := find(restricted, "restrictedVolumes")
+	require.NotNil(t, volumes)
+	assert.False(t, volumes.Result.Allowed)
+	assert.Equal(t, api.LevelRestricted, volumes.Result.Level)
+	assert.Equal(t, api.MajorMinorVersion(1, 0), volumes.Result.MinimumVersion)
+	assert.Equal(t, []CheckID{"hostPathVolumes"}, volumes.Overrides)
+	capabilities := find(restricted, "capabilities_restricted")
+	require.NotNil(t, capabilities)
+	assert.Equal(t, api.MajorMinorVersion(1, 25), capabilities.Result.MinimumVersion, "newest revision should be selected")
+	assert.Equal(t, evaluator.EvaluatePod(restricted.Requested, &pod.ObjectMeta, &pod.Spec), restricted.Results())
+
+	old := evaluator.ExplainPod(api.LevelVersion{Level: api.LevelRestricted, Version: api.MajorMinorVersion(1, 22)}, &pod.ObjectMeta, &pod.Spec)
+	assert.Equal(t, api.MajorMinorVersion(1, 22), old.Evaluated.Version)
+	capabilities = find(old, "capabilities_restricted")
+	require.NotNil(t, capabilities)
+	assert.Equal(t, api.MajorMinorVersion(1, 22), capabilities.Result.MinimumVersion)
+
+	baseline := evaluator.ExplainPod(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec)
+	hostPath := find(baseline, "hostPathVolumes")
+	require.NotNil(t, hostPath)
+	assert.False(t, hostPath.Result.Allowed)
+	assert.Empty(t, hostPath.Overrides)
+	assert.Nil(t, find(baseline, "restrictedVolumes"))
+
+	privileged := evaluator.ExplainPod(api.LevelVersion{Level: api.LevelPrivileged, Version: api.LatestVersion()}, &pod.ObjectMeta, &pod.Spec)
+	assert.Empty(t, privileged.Checks)
+}
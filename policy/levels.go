@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+
+	"k8s.io/pod-security-admission/api"
+)
+
+// CustomLevel defines a level by the checks it adds to or removes from a built-in level.
+// Custom levels must also be ordered in an api.LevelSet to be accepted as level labels.
+type CustomLevel struct {
+	// Name is the name of the level. It must not be a built-in level.
+	Name api.Level
+	// Base is the built-in level whose checks are evaluated for this level.
+	Base api.Level
+	// AddChecks lists the IDs of checks to evaluate in addition to the checks of the base level.
+	// Overrides of added restricted checks are honored, so adding a restricted check to a baseline
+	// level replaces the baseline checks it overrides.
+	AddChecks []CheckID
+	// RemoveChecks lists the IDs of checks of the base level to skip.
+	RemoveChecks []CheckID
+}
+
+func validateCustomLevels(checks []Check, levels []CustomLevel) error {
+	ids := map[CheckID]bool{}
+	for _, check := range checks {
+		ids[check.ID] = true
+	}
+	names := map[api.Level]bool{}
+	for _, level := range levels {
+		switch level.Name {
+		case "":
+			return fmt.Errorf("custom level name must not be empty")
+		case api.LevelPrivileged, api.LevelBaseline, api.LevelRestricted:
+			return fmt.Errorf("custom level %s: must not be a built-in level", level.Name)
+		}
+		if names[level.Name] {
+			return fmt.Errorf("multiple custom levels named %s", level.Name)
+		}
+		names[level.Name] = true
+		switch level.Base {
+		case api.LevelPrivileged, api.LevelBaseline, api.LevelRestricted:
+		default:
+			return fmt.Errorf("custom level %s: invalid base level %s", level.Name, level.Base)
+		}
+		added := map[CheckID]bool{}
+		for _, id := range level.AddChecks {
+			if !ids[id] {
+				return fmt.Errorf("custom level %s: unknown check %s", level.Name, id)
+			}
+			added[id] = true
+		}
+		for _, id := range level.RemoveChecks {
+			if !ids[id] {
+				return fmt.Errorf("custom level %s: unknown check %s", level.Name, id)
+			}
+			if added[id] {
+				return fmt.Errorf("custom level %s: check %s is both added and removed", level.Name, id)
+			}
+		}
+	}
+	return nil
+}
+
+// populateCustomLevels derives the checks of each custom level and policy version from the checks of the
+// base level. populate must have been called first.
+func populateCustomLevels(r *checkRegistry, validChecks []Check, levels []CustomLevel) {
+	if len(levels) == 0 {
+		return
+	}
+	versionedChecks := map[api.Version]map[CheckID]registeredCheck{}
+	for _, c := range validChecks {
+		inflateVersions(c, versionedChecks, r.maxVersion)
+	}
+	orderedIDs := orderedCheckIDs(validChecks)
+
+	for _, level := range levels {
+		levelChecks := map[api.Version][]registeredCheck{}
+		for v := api.MajorMinorVersion(1, 0); v.Older(nextMinor(r.maxVersion)); v = nextMinor(v) {
+			checks := map[CheckID]registeredCheck{}
+			var baseChecks []registeredCheck
+			switch level.Base {
+			case api.LevelBaseline:
+				baseChecks = r.baselineChecks[v]
+			case api.LevelRestricted:
+				baseChecks = r.restrictedChecks[v]
+			}
+			for _, c := range baseChecks {
+				checks[c.id] = c
+			}
+			for _, id := range level.RemoveChecks {
+				delete(checks, id)
+			}
+			for _, id := range level.AddChecks {
+				c, ok := versionedChecks[v][id]
+				if !ok {
+					continue // Not yet assigned to this version.
+				}
+				checks[id] = c
+				for _, override := range c.OverrideCheckIDs {
+					delete(checks, override)
+				}
+			}
+			levelChecks[v] = mapChecks(checks, orderedIDs)
+		}
+		r.customLevelChecks[level.Name] = levelChecks
+	}
+}
@@ -23,6 +23,12 @@ import (
 
 type options struct {
 	withFieldErrors bool
+	// allowLists overrides the allow-lists of checks at the evaluated level.
+	allowLists *LevelAllowLists
+	// shortCircuit stops the evaluation at the first check that forbids the pod.
+	shortCircuit bool
+	// parallelism is the maximum number of checks evaluated concurrently.
+	parallelism int
 }
 
 type Option func(options) options
@@ -45,3 +51,66 @@ func WithFieldErrors() Option {
 		return opt
 	}
 }
+
+// WithShortCircuit makes EvaluatePod stop at the first check that forbids the pod, and return the results
+// up to and including that check. It suits decisions that only need to know whether the pod is allowed.
+func WithShortCircuit() Option {
+	return func(opt options) options {
+		opt.shortCircuit = true
+		return opt
+	}
+}
+
+// WithParallelism makes EvaluatePod evaluate up to n checks concurrently, which lowers the latency of
+// evaluating pods with many containers. Results are returned in the same order as sequential evaluation.
+// Checks must be safe for concurrent use; all built-in checks are.
+func WithParallelism(n int) Option {
+	return func(opt options) options {
+		opt.parallelism = n
+		return opt
+	}
+}
+
+type evaluatorOptions struct {
+	customLevels []CustomLevel
+	checks       []Check
+	celChecks    []CELCheck
+	allowLists   []LevelAllowLists
+}
+
+// EvaluatorOption configures an Evaluator constructed by NewEvaluator.
+type EvaluatorOption func(evaluatorOptions) evaluatorOptions
+
+// WithCustomLevels makes the Evaluator evaluate the custom levels.
+func WithCustomLevels(levels ...CustomLevel) EvaluatorOption {
+	return func(opt evaluatorOptions) evaluatorOptions {
+		opt.customLevels = append(opt.customLevels, levels...)
+		return opt
+	}
+}
+
+// WithChecks makes the Evaluator evaluate the checks alongside the checks it is constructed with.
+// This adds optional checks, such as CheckImages, without registering them with RegisterCheck.
+func WithChecks(checks ...Check) EvaluatorOption {
+	return func(opt evaluatorOptions) evaluatorOptions {
+		opt.checks = append(opt.checks, checks...)
+		return opt
+	}
+}
+
+// WithCELChecks makes the Evaluator evaluate the CEL checks alongside the checks it is constructed with.
+// The expressions are compiled when the Evaluator is constructed.
+func WithCELChecks(checks ...CELCheck) EvaluatorOption {
+	return func(opt evaluatorOptions) evaluatorOptions {
+		opt.celChecks = append(opt.celChecks, checks...)
+		return opt
+	}
+}
+
+// WithAllowLists makes the Evaluator override the allow-lists of checks at the given levels.
+func WithAllowLists(lists ...LevelAllowLists) EvaluatorOption {
+	return func(opt evaluatorOptions) evaluatorOptions {
+		opt.allowLists = append(opt.allowLists, lists...)
+		return opt
+	}
+}
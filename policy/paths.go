@@ -19,23 +19,24 @@ package policy
 import "k8s.io/apimachinery/pkg/util/validation/field"
 
 var (
-	annotationsPath            = field.NewPath("metadata", "annotations")
-	specPath                   = field.NewPath("spec")
-	initContainersFldPath      = specPath.Child("initContainers")
-	containersFldPath          = specPath.Child("containers")
-	ephemeralContainersFldPath = specPath.Child("ephemeralContainers")
-	securityContextPath        = specPath.Child("securityContext")
-	hostNetworkPath            = specPath.Child("hostNetwork")
-	hostPIDPath                = specPath.Child("hostPID")
-	hostIPCPath                = specPath.Child("hostIPC")
-	volumesPath                = specPath.Child("volumes")
-	runAsNonRootPath           = securityContextPath.Child("runAsNonRoot")
-	runAsUserPath              = securityContextPath.Child("runAsUser")
-	seccompProfileTypePath     = securityContextPath.Child("seccompProfile", "type")
-	seLinuxOptionsTypePath     = securityContextPath.Child("seLinuxOptions", "type")
-	seLinuxOptionsUserPath     = securityContextPath.Child("seLinuxOptions", "user")
-	seLinuxOptionsRolePath     = securityContextPath.Child("seLinuxOptions", "role")
-	sysctlsPath                = securityContextPath.Child("sysctls")
-	hostProcessPath            = securityContextPath.Child("windowsOptions", "hostProcess")
-	appArmorProfileTypePath    = securityContextPath.Child("appArmorProfile", "type")
+	annotationsPath                  = field.NewPath("metadata", "annotations")
+	specPath                         = field.NewPath("spec")
+	initContainersFldPath            = specPath.Child("initContainers")
+	containersFldPath                = specPath.Child("containers")
+	ephemeralContainersFldPath       = specPath.Child("ephemeralContainers")
+	securityContextPath              = specPath.Child("securityContext")
+	hostNetworkPath                  = specPath.Child("hostNetwork")
+	hostPIDPath                      = specPath.Child("hostPID")
+	hostIPCPath                      = specPath.Child("hostIPC")
+	volumesPath                      = specPath.Child("volumes")
+	automountServiceAccountTokenPath = specPath.Child("automountServiceAccountToken")
+	runAsNonRootPath                 = securityContextPath.Child("runAsNonRoot")
+	runAsUserPath                    = securityContextPath.Child("runAsUser")
+	seccompProfileTypePath           = securityContextPath.Child("seccompProfile", "type")
+	seLinuxOptionsTypePath           = securityContextPath.Child("seLinuxOptions", "type")
+	seLinuxOptionsUserPath           = securityContextPath.Child("seLinuxOptions", "user")
+	seLinuxOptionsRolePath           = securityContextPath.Child("seLinuxOptions", "role")
+	sysctlsPath                      = securityContextPath.Child("sysctls")
+	hostProcessPath                  = securityContextPath.Child("windowsOptions", "hostProcess")
+	appArmorProfileTypePath          = securityContextPath.Child("appArmorProfile", "type")
 )
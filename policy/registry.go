@@ -19,6 +19,8 @@ package policy
 import (
 	"fmt"
 	"sort"
+	"sync"
+	"sync/atomic"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,16 +30,23 @@ import (
 // Evaluator holds the Checks that are used to validate a policy.
 type Evaluator interface {
 	// EvaluatePod evaluates the pod against the policy for the given level & version.
-	EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec) []CheckResult
+	// The options are passed through to every evaluated check.
+	EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) []CheckResult
 }
 
 // checkRegistry provides a default implementation of an Evaluator.
 type checkRegistry struct {
 	// The checks are a map policy version to a slice of checks registered for that version.
-	baselineChecks, restrictedChecks map[api.Version][]CheckPodFn
+	baselineChecks, restrictedChecks map[api.Version][]registeredCheck
+	// customLevelChecks maps custom levels to the checks of each policy version.
+	customLevelChecks map[api.Level]map[api.Version][]registeredCheck
+	// allowLists maps levels to the overrides of the allow-lists of their checks.
+	allowLists map[api.Level]*LevelAllowLists
 	// maxVersion is the maximum version that is cached, guaranteed to be at least
 	// the max MinimumVersion of all registered checks.
 	maxVersion api.Version
+	// readsMetadata is set if some checks may read pod metadata other than the labels and annotations.
+	readsMetadata bool
 }
 
 // NewEvaluator constructs a new Evaluator instance from the list of checks. If the provided checks are invalid,
@@ -46,15 +55,48 @@ type checkRegistry struct {
 // 2. Check.Level must be either Baseline or Restricted
 // 3. Checks must have a non-empty set of versions, sorted in a strictly increasing order
 // 4. Check.Versions cannot include 'latest'
-func NewEvaluator(checks []Check, emulationVersion *api.Version) (*checkRegistry, error) {
+// Checks added by options, such as optional checks and CEL checks, must meet the same requirements.
+func NewEvaluator(checks []Check, emulationVersion *api.Version, opts ...EvaluatorOption) (*checkRegistry, error) {
+	var o evaluatorOptions
+	for _, opt := range opts {
+		if opt != nil {
+			o = opt(o)
+		}
+	}
+	if len(o.checks) > 0 || len(o.celChecks) > 0 {
+		checks = append(append([]Check(nil), checks...), o.checks...)
+	}
+	if len(o.celChecks) > 0 {
+		env, err := newCELEnv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+		}
+		for _, c := range o.celChecks {
+			check, err := compileCELCheck(env, c)
+			if err != nil {
+				return nil, err
+			}
+			checks = append(checks, check)
+		}
+	}
 	if err := validateChecks(checks); err != nil {
 		return nil, err
 	}
+	if err := validateCustomLevels(checks, o.customLevels); err != nil {
+		return nil, err
+	}
+	if err := validateAllowLists(o.allowLists, o.customLevels); err != nil {
+		return nil, err
+	}
 	r := &checkRegistry{
-		baselineChecks:   map[api.Version][]CheckPodFn{},
-		restrictedChecks: map[api.Version][]CheckPodFn{},
+		baselineChecks:    map[api.Version][]registeredCheck{},
+		restrictedChecks:  map[api.Version][]registeredCheck{},
+		customLevelChecks: map[api.Level]map[api.Version][]registeredCheck{},
 	}
 	populate(r, checks)
+	r.readsMetadata = len(o.celChecks) > 0 || !onlyBuiltinChecks(checks)
+	populateCustomLevels(r, checks, o.customLevels)
+	populateAllowLists(r, o.allowLists, o.customLevels)
 
 	// lower the max version if we're emulating an older minor
 	if emulationVersion != nil && (*emulationVersion).Older(r.maxVersion) {
@@ -64,27 +106,142 @@ func NewEvaluator(checks []Check, emulationVersion *api.Version) (*checkRegistry
 	return r, nil
 }
 
-func (r *checkRegistry) EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec) []CheckResult {
+// onlyBuiltinChecks returns true if all the checks are checks of this package, which only read
+// the labels and annotations of the pod metadata.
+func onlyBuiltinChecks(checks []Check) bool {
+	builtin := map[CheckID]bool{}
+	packageChecks := append(append(DefaultChecks(), ExperimentalChecks()...), HardenedChecks()...)
+	for _, c := range append(packageChecks, CheckImages(ImagePolicy{}), CheckResources(ResourcePolicy{})) {
+		builtin[c.ID] = true
+	}
+	for _, c := range checks {
+		if !builtin[c.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// readsPodMetadata implements podMetadataReader.
+func (r *checkRegistry) readsPodMetadata() bool {
+	return r.readsMetadata
+}
+
+func (r *checkRegistry) EvaluatePod(lv api.LevelVersion, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts ...Option) []CheckResult {
+	_, checks, opts := r.checksFor(lv, opts)
+	var o options
+	for _, opt := range opts {
+		if opt != nil {
+			o = opt(o)
+		}
+	}
+	if o.parallelism > 1 && len(checks) > 1 {
+		return evaluateParallel(checks, podMetadata, podSpec, opts, o)
+	}
+
+	var results []CheckResult
+	for _, check := range checks {
+		result := check.evaluate(podMetadata, podSpec, opts)
+		results = append(results, result)
+		if o.shortCircuit && !result.Allowed {
+			break
+		}
+	}
+	return results
+}
+
+// evaluateParallel evaluates the checks with up to o.parallelism workers, and returns the results in check order.
+// With short-circuiting, checks after the first check known to forbid the pod are skipped if they have not
+// started, and the results are truncated after the first forbidden result. Checks before it are always evaluated.
+func evaluateParallel(checks []registeredCheck, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts []Option, o options) []CheckResult {
+	results := make([]CheckResult, len(checks))
+	evaluated := make([]bool, len(checks))
+	var (
+		next atomic.Int64
+		// firstFailed is the lowest index of the checks known to forbid the pod.
+		firstFailed atomic.Int64
+		wg          sync.WaitGroup
+	)
+	firstFailed.Store(int64(len(checks)))
+	for w := 0; w < min(o.parallelism, len(checks)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(checks) || (o.shortCircuit && int64(i) > firstFailed.Load()) {
+					return
+				}
+				results[i] = checks[i].evaluate(podMetadata, podSpec, opts)
+				evaluated[i] = true
+				if !results[i].Allowed {
+					for failed := firstFailed.Load(); int64(i) < failed; failed = firstFailed.Load() {
+						if firstFailed.CompareAndSwap(failed, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	ordered := results[:0]
+	for i := range results {
+		if !evaluated[i] {
+			continue
+		}
+		ordered = append(ordered, results[i])
+		if o.shortCircuit && !results[i].Allowed {
+			break
+		}
+	}
+	return ordered
+}
+
+// checksFor returns the level and version to evaluate after clamping the version, the checks to evaluate,
+// and the options to evaluate them with.
+func (r *checkRegistry) checksFor(lv api.LevelVersion, opts []Option) (api.LevelVersion, []registeredCheck, []Option) {
 	if lv.Level == api.LevelPrivileged {
-		return nil
+		return lv, nil, opts
 	}
 	if r.maxVersion.Older(lv.Version) {
 		lv.Version = r.maxVersion
 	}
 
-	var checks []CheckPodFn
+	var checks []registeredCheck
 	if lv.Level == api.LevelBaseline {
 		checks = r.baselineChecks[lv.Version]
+	} else if customChecks, ok := r.customLevelChecks[lv.Level]; ok {
+		checks = customChecks[lv.Version]
 	} else {
 		// includes non-overridden baseline checks
 		checks = r.restrictedChecks[lv.Version]
 	}
 
-	var results []CheckResult
-	for _, check := range checks {
-		results = append(results, check(podMetadata, podSpec))
+	if lists, ok := r.allowLists[lv.Level]; ok {
+		opts = append(opts[:len(opts):len(opts)], withAllowLists(lists))
 	}
-	return results
+	return lv, checks, opts
+}
+
+// registeredCheck is the revision of a check selected for a policy version.
+type registeredCheck struct {
+	VersionedCheck
+
+	// id is the ID of the check the revision belongs to.
+	id CheckID
+	// level is the level of the check the revision belongs to.
+	level api.Level
+}
+
+// evaluate runs the check and attributes the result to it.
+func (c *registeredCheck) evaluate(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, opts []Option) CheckResult {
+	result := c.CheckPod(podMetadata, podSpec, opts...)
+	result.CheckID = c.id
+	result.Level = c.level
+	result.MinimumVersion = c.MinimumVersion
+	return result
 }
 
 func validateChecks(checks []Check) error {
@@ -147,25 +304,17 @@ func populate(r *checkRegistry, validChecks []Check) {
 	}
 
 	var (
-		restrictedVersionedChecks = map[api.Version]map[CheckID]VersionedCheck{}
-		baselineVersionedChecks   = map[api.Version]map[CheckID]VersionedCheck{}
-
-		baselineIDs, restrictedIDs []CheckID
+		restrictedVersionedChecks = map[api.Version]map[CheckID]registeredCheck{}
+		baselineVersionedChecks   = map[api.Version]map[CheckID]registeredCheck{}
 	)
 	for _, c := range validChecks {
 		if c.Level == api.LevelRestricted {
-			restrictedIDs = append(restrictedIDs, c.ID)
 			inflateVersions(c, restrictedVersionedChecks, r.maxVersion)
 		} else {
-			baselineIDs = append(baselineIDs, c.ID)
 			inflateVersions(c, baselineVersionedChecks, r.maxVersion)
 		}
 	}
-
-	// Sort the IDs to maintain consistent error messages.
-	sort.Slice(restrictedIDs, func(i, j int) bool { return restrictedIDs[i] < restrictedIDs[j] })
-	sort.Slice(baselineIDs, func(i, j int) bool { return baselineIDs[i] < baselineIDs[j] })
-	orderedIDs := append(baselineIDs, restrictedIDs...) // Baseline checks first, then restricted.
+	orderedIDs := orderedCheckIDs(validChecks)
 
 	for v := api.MajorMinorVersion(1, 0); v.Older(nextMinor(r.maxVersion)); v = nextMinor(v) {
 		// Aggregate all the overridden baseline check ids.
@@ -181,17 +330,33 @@ func populate(r *checkRegistry, validChecks []Check) {
 				continue // Overridden check: skip it.
 			}
 			if restrictedVersionedChecks[v] == nil {
-				restrictedVersionedChecks[v] = map[CheckID]VersionedCheck{}
+				restrictedVersionedChecks[v] = map[CheckID]registeredCheck{}
 			}
 			restrictedVersionedChecks[v][id] = c
 		}
 
-		r.restrictedChecks[v] = mapCheckPodFns(restrictedVersionedChecks[v], orderedIDs)
-		r.baselineChecks[v] = mapCheckPodFns(baselineVersionedChecks[v], orderedIDs)
+		r.restrictedChecks[v] = mapChecks(restrictedVersionedChecks[v], orderedIDs)
+		r.baselineChecks[v] = mapChecks(baselineVersionedChecks[v], orderedIDs)
+	}
+}
+
+// orderedCheckIDs returns the IDs of the checks in evaluation order: baseline checks first, then restricted.
+func orderedCheckIDs(checks []Check) []CheckID {
+	var baselineIDs, restrictedIDs []CheckID
+	for _, c := range checks {
+		if c.Level == api.LevelRestricted {
+			restrictedIDs = append(restrictedIDs, c.ID)
+		} else {
+			baselineIDs = append(baselineIDs, c.ID)
+		}
 	}
+	// Sort the IDs to maintain consistent error messages.
+	sort.Slice(restrictedIDs, func(i, j int) bool { return restrictedIDs[i] < restrictedIDs[j] })
+	sort.Slice(baselineIDs, func(i, j int) bool { return baselineIDs[i] < baselineIDs[j] })
+	return append(baselineIDs, restrictedIDs...)
 }
 
-func inflateVersions(check Check, versions map[api.Version]map[CheckID]VersionedCheck, maxVersion api.Version) {
+func inflateVersions(check Check, versions map[api.Version]map[CheckID]registeredCheck, maxVersion api.Version) {
 	for i, c := range check.Versions {
 		var nextVersion api.Version
 		if i+1 < len(check.Versions) {
@@ -204,23 +369,23 @@ func inflateVersions(check Check, versions map[api.Version]map[CheckID]Versioned
 		// next check, or the maxVersion++.
 		for v := c.MinimumVersion; v.Older(nextVersion); v = nextMinor(v) {
 			if versions[v] == nil {
-				versions[v] = map[CheckID]VersionedCheck{}
+				versions[v] = map[CheckID]registeredCheck{}
 			}
-			versions[v][check.ID] = check.Versions[i]
+			versions[v][check.ID] = registeredCheck{VersionedCheck: check.Versions[i], id: check.ID, level: check.Level}
 		}
 	}
 }
 
-// mapCheckPodFns converts the versioned check map to an ordered slice of CheckPodFn,
+// mapChecks converts the versioned check map to an ordered slice of checks,
 // using the order specified by orderedIDs. All checks must have a corresponding ID in orderedIDs.
-func mapCheckPodFns(checks map[CheckID]VersionedCheck, orderedIDs []CheckID) []CheckPodFn {
-	fns := make([]CheckPodFn, 0, len(checks))
+func mapChecks(checks map[CheckID]registeredCheck, orderedIDs []CheckID) []registeredCheck {
+	ordered := make([]registeredCheck, 0, len(checks))
 	for _, id := range orderedIDs {
 		if check, ok := checks[id]; ok {
-			fns = append(fns, check.CheckPod)
+			ordered = append(ordered, check)
 		}
 	}
-	return fns
+	return ordered
 }
 
 // nextMinor increments the minor version
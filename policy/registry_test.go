@@ -19,9 +19,11 @@ package policy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/api"
 
 	"github.com/stretchr/testify/assert"
@@ -142,6 +144,203 @@ func TestCheckRegistry_Empty(t *testing.T) {
 	}
 }
 
+func TestCheckRegistry_Attribution(t *testing.T) {
+	checks := []Check{
+		generateCheck("a", api.LevelBaseline, []string{"v1.0", "v1.5"}),
+		generateCheck("b", api.LevelRestricted, []string{"v1.3"}),
+	}
+	reg, err := NewEvaluator(checks, nil)
+	require.NoError(t, err)
+
+	results := reg.EvaluatePod(api.LevelVersion{Level: api.LevelRestricted, Version: api.MajorMinorVersion(1, 4)}, nil, nil)
+	require.Len(t, results, 2)
+	assert.Equal(t, CheckID("a"), results[0].CheckID)
+	assert.Equal(t, api.LevelBaseline, results[0].Level)
+	assert.Equal(t, api.MajorMinorVersion(1, 0), results[0].MinimumVersion)
+	assert.Equal(t, CheckID("b"), results[1].CheckID)
+	assert.Equal(t, api.LevelRestricted, results[1].Level)
+	assert.Equal(t, api.MajorMinorVersion(1, 3), results[1].MinimumVersion)
+
+	results = reg.EvaluatePod(api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}, nil, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, CheckID("a"), results[0].CheckID)
+	assert.Equal(t, api.MajorMinorVersion(1, 5), results[0].MinimumVersion)
+
+	// Checks sharing a forbidden reason are attributed individually.
+	aggregate := AggregateCheckResults([]CheckResult{
+		{ForbiddenReason: "shared", CheckID: "x", ErrList: &field.ErrorList{field.Forbidden(field.NewPath("x"), "")}},
+		{ForbiddenReason: "shared", CheckID: "y", ErrList: &field.ErrorList{field.Forbidden(field.NewPath("y"), "")}},
+	})
+	require.Len(t, aggregate.ForbiddenResults, 2)
+	assert.Equal(t, CheckID("x"), aggregate.ForbiddenResults[0].CheckID)
+	assert.Equal(t, "x", (*aggregate.ForbiddenResults[0].ErrList)[0].Field)
+	assert.Equal(t, CheckID("y"), aggregate.ForbiddenResults[1].CheckID)
+	assert.Equal(t, "y", (*aggregate.ForbiddenResults[1].ErrList)[0].Field)
+}
+
+func TestCheckRegistry_ShortCircuit(t *testing.T) {
+	checks := []Check{
+		generateCheck("a", api.LevelBaseline, []string{"v1.0"}),
+		generateCheck("b", api.LevelBaseline, []string{"v1.0"}),
+		generateCheck("c", api.LevelRestricted, []string{"v1.0"}),
+	}
+	reg, err := NewEvaluator(checks, nil)
+	require.NoError(t, err)
+	lv := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+
+	results := reg.EvaluatePod(lv, nil, nil, WithShortCircuit())
+	require.Len(t, results, 1)
+	assert.Equal(t, "a:v1.0", results[0].ForbiddenReason)
+
+	// Concurrent checks may forbid the pod first, but results still stop at the first failure.
+	results = reg.EvaluatePod(lv, nil, nil, WithShortCircuit(), WithParallelism(3))
+	require.Len(t, results, 1)
+	assert.Equal(t, "a:v1.0", results[0].ForbiddenReason)
+}
+
+func TestCheckRegistry_ShortCircuitParallel(t *testing.T) {
+	passing := func(id CheckID, delay time.Duration) Check {
+		return Check{ID: id, Level: api.LevelBaseline, Versions: []VersionedCheck{{
+			MinimumVersion: api.MajorMinorVersion(1, 0),
+			CheckPod: func(_ *metav1.ObjectMeta, _ *corev1.PodSpec, _ ...Option) CheckResult {
+				time.Sleep(delay)
+				return CheckResult{Allowed: true}
+			},
+		}}}
+	}
+	// A slow passing check ahead of a failing one must not be skipped, nor must the checks
+	// between them that have not started when the failing check completes.
+	checks := []Check{
+		passing("a", 10*time.Millisecond),
+		passing("b", 0),
+		passing("c", 0),
+		generateCheck("d", api.LevelBaseline, []string{"v1.0"}),
+		passing("e", 0),
+		generateCheck("f", api.LevelBaseline, []string{"v1.0"}),
+	}
+	reg, err := NewEvaluator(checks, nil)
+	require.NoError(t, err)
+	lv := api.LevelVersion{Level: api.LevelBaseline, Version: api.LatestVersion()}
+
+	expected := reg.EvaluatePod(lv, nil, nil, WithShortCircuit())
+	require.Len(t, expected, 4)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, expected, reg.EvaluatePod(lv, nil, nil, WithShortCircuit(), WithParallelism(len(checks))))
+	}
+}
+
+func TestCheckRegistry_Parallel(t *testing.T) {
+	reg, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(t, err)
+	pod := largePod(100)
+
+	for _, level := range []api.Level{api.LevelBaseline, api.LevelRestricted} {
+		lv := api.LevelVersion{Level: level, Version: api.LatestVersion()}
+		expected := reg.EvaluatePod(lv, &pod.ObjectMeta, &pod.Spec, WithFieldErrors())
+		for _, parallelism := range []int{2, 4, 100} {
+			actual := reg.EvaluatePod(lv, &pod.ObjectMeta, &pod.Spec, WithFieldErrors(), WithParallelism(parallelism))
+			assert.Equal(t, expected, actual, "%s with parallelism %d", level, parallelism)
+		}
+	}
+}
+
+func BenchmarkEvaluatePod(b *testing.B) {
+	reg, err := NewEvaluator(DefaultChecks(), nil)
+	require.NoError(b, err)
+	lv := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+
+	for _, containers := range []int{10, 500} {
+		pod := largePod(containers)
+		for _, bc := range []struct {
+			name string
+			opts []Option
+		}{
+			{name: "sequential"},
+			{name: "short-circuit", opts: []Option{WithShortCircuit()}},
+			// Latency only improves with GOMAXPROCS > 1.
+			{name: "parallel", opts: []Option{WithParallelism(8)}},
+		} {
+			b.Run(fmt.Sprintf("%d containers/%s", containers, bc.name), func(b *testing.B) {
+				opts := append([]Option{WithFieldErrors()}, bc.opts...)
+				for i := 0; i < b.N; i++ {
+					reg.EvaluatePod(lv, &pod.ObjectMeta, &pod.Spec, opts...)
+				}
+			})
+		}
+	}
+}
+
+// largePod returns a pod with the given number of containers, which violate the restricted level
+// and pass the baseline level.
+func largePod(containers int) *corev1.Pod {
+	pod := &corev1.Pod{}
+	for i := 0; i < containers; i++ {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:  fmt.Sprintf("container%d", i),
+			Image: "registry.k8s.io/pause",
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"CHOWN"}},
+			},
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+		})
+	}
+	return pod
+}
+
+func TestCheckRegistry_CustomLevels(t *testing.T) {
+	checks := []Check{
+		generateCheck("a", api.LevelBaseline, []string{"v1.0"}),
+		generateCheck("b", api.LevelBaseline, []string{"v1.0", "v1.10"}),
+		generateCheck("c", api.LevelRestricted, []string{"v1.0"}),
+		withOverrides(generateCheck("d", api.LevelRestricted, []string{"v1.5"}), []CheckID{"a"}),
+		generateCheck("e", api.LevelRestricted, []string{"v1.20"}),
+	}
+	reg, err := NewEvaluator(checks, nil, WithCustomLevels(
+		CustomLevel{Name: "baseline-minus-b", Base: api.LevelBaseline, RemoveChecks: []CheckID{"b"}},
+		CustomLevel{Name: "baseline-plus-d", Base: api.LevelBaseline, AddChecks: []CheckID{"d"}},
+		CustomLevel{Name: "restricted-minus-c", Base: api.LevelRestricted, RemoveChecks: []CheckID{"c"}},
+		CustomLevel{Name: "privileged-plus-e", Base: api.LevelPrivileged, AddChecks: []CheckID{"e"}},
+	))
+	require.NoError(t, err)
+
+	levelCases := []registryTestCase{
+		{"baseline-minus-b", "v1.0", []string{"a:v1.0"}},
+		{"baseline-minus-b", "latest", []string{"a:v1.0"}},
+		{"baseline-plus-d", "v1.4", []string{"a:v1.0", "b:v1.0"}},
+		{"baseline-plus-d", "v1.5", []string{"b:v1.0", "d:v1.5"}},
+		{"baseline-plus-d", "latest", []string{"b:v1.10", "d:v1.5"}},
+		{"restricted-minus-c", "v1.0", []string{"a:v1.0", "b:v1.0"}},
+		{"restricted-minus-c", "latest", []string{"b:v1.10", "d:v1.5", "e:v1.20"}},
+		{"privileged-plus-e", "v1.19", nil},
+		{"privileged-plus-e", "latest", []string{"e:v1.20"}},
+		// Unknown levels are evaluated as restricted.
+		{"unknown", "latest", []string{"b:v1.10", "c:v1.0", "d:v1.5", "e:v1.20"}},
+	}
+	for _, test := range levelCases {
+		test.Run(t, reg)
+	}
+
+	for _, tc := range []struct {
+		level       CustomLevel
+		expectedErr string
+	}{
+		{CustomLevel{Base: api.LevelBaseline}, "custom level name must not be empty"},
+		{CustomLevel{Name: api.LevelRestricted, Base: api.LevelBaseline}, "custom level restricted: must not be a built-in level"},
+		{CustomLevel{Name: "x", Base: "x"}, "custom level x: invalid base level x"},
+		{CustomLevel{Name: "x", Base: api.LevelBaseline, AddChecks: []CheckID{"z"}}, "custom level x: unknown check z"},
+		{CustomLevel{Name: "x", Base: api.LevelBaseline, RemoveChecks: []CheckID{"z"}}, "custom level x: unknown check z"},
+		{CustomLevel{Name: "x", Base: api.LevelBaseline, AddChecks: []CheckID{"c"}, RemoveChecks: []CheckID{"c"}}, "custom level x: check c is both added and removed"},
+	} {
+		_, err := NewEvaluator(checks, nil, WithCustomLevels(tc.level))
+		assert.EqualError(t, err, tc.expectedErr)
+	}
+	_, err = NewEvaluator(checks, nil, WithCustomLevels(
+		CustomLevel{Name: "x", Base: api.LevelBaseline},
+		CustomLevel{Name: "x", Base: api.LevelRestricted},
+	))
+	assert.EqualError(t, err, "multiple custom levels named x")
+}
+
 type registryTestCase struct {
 	level           api.Level
 	version         string
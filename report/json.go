@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+type jsonReport struct {
+	Results []jsonResult `json:"results"`
+}
+
+type jsonResult struct {
+	Object      jsonObject       `json:"object"`
+	Level       string           `json:"level"`
+	Version     string           `json:"version"`
+	Allowed     bool             `json:"allowed"`
+	Violations  []jsonViolation  `json:"violations,omitempty"`
+	Explanation *jsonExplanation `json:"explanation,omitempty"`
+}
+
+type jsonExplanation struct {
+	Level          string               `json:"level"`
+	Version        string               `json:"version"`
+	DurationMicros int64                `json:"durationMicros"`
+	Checks         []jsonExplainedCheck `json:"checks"`
+}
+
+type jsonExplainedCheck struct {
+	Check          string   `json:"check"`
+	Level          string   `json:"level"`
+	MinimumVersion string   `json:"minimumVersion"`
+	Allowed        bool     `json:"allowed"`
+	Overrides      []string `json:"overrides,omitempty"`
+	DurationMicros int64    `json:"durationMicros"`
+}
+
+type jsonObject struct {
+	Source string `json:"source,omitempty"`
+	// Document is only set for objects read from a source, and may be zero.
+	Document   *int   `json:"document,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type jsonViolation struct {
+	Check          string      `json:"check,omitempty"`
+	Level          string      `json:"level,omitempty"`
+	MinimumVersion string      `json:"minimumVersion,omitempty"`
+	Reason         string      `json:"reason"`
+	Detail         string      `json:"detail,omitempty"`
+	Fields         []jsonField `json:"fields,omitempty"`
+}
+
+type jsonField struct {
+	Path  string      `json:"path"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// writeJSON writes a single JSON document containing all entries, including allowed entries.
+func writeJSON(w io.Writer, entries []Entry) error {
+	report := jsonReport{Results: make([]jsonResult, 0, len(entries))}
+	for i := range entries {
+		e := &entries[i]
+		result := jsonResult{
+			Object: jsonObject{
+				Source:     e.Object.Source,
+				APIVersion: e.Object.APIVersion,
+				Kind:       e.Object.Kind,
+				Namespace:  e.Object.Namespace,
+				Name:       e.Object.Name,
+			},
+			Level:   string(e.Policy.Level),
+			Version: e.Policy.Version.String(),
+			Allowed: e.Result.Allowed,
+		}
+		if e.Object.Source != "" {
+			document := e.Object.Document
+			result.Object.Document = &document
+		}
+		for _, v := range e.Violations() {
+			violation := jsonViolation{
+				Check:  string(v.CheckID),
+				Level:  string(v.Level),
+				Reason: v.Reason,
+				Detail: v.Detail,
+			}
+			if v.MinimumVersion != (api.Version{}) {
+				violation.MinimumVersion = v.MinimumVersion.String()
+			}
+			for _, err := range v.Fields {
+				violation.Fields = append(violation.Fields, jsonField{
+					Path:  err.Field,
+					Type:  string(err.Type),
+					Value: badValue(err.BadValue),
+				})
+			}
+			result.Violations = append(result.Violations, violation)
+		}
+		if e.Explanation != nil {
+			result.Explanation = explanationJSON(e.Explanation)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// explanationJSON converts an explanation, reporting the evaluated level and version and durations in microseconds.
+func explanationJSON(explanation *policy.Explanation) *jsonExplanation {
+	result := &jsonExplanation{
+		Level:          string(explanation.Evaluated.Level),
+		Version:        explanation.Evaluated.Version.String(),
+		DurationMicros: explanation.Duration.Microseconds(),
+		Checks:         []jsonExplainedCheck{},
+	}
+	for _, c := range explanation.Checks {
+		check := jsonExplainedCheck{
+			Check:          string(c.Result.CheckID),
+			Level:          string(c.Result.Level),
+			MinimumVersion: c.Result.MinimumVersion.String(),
+			Allowed:        c.Result.Allowed,
+			DurationMicros: c.Duration.Microseconds(),
+		}
+		for _, id := range c.Overrides {
+			check.Overrides = append(check.Overrides, string(id))
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	return result
+}
+
+// badValue returns the bad value of a field error, or nil for required fields which have no value.
+func badValue(value interface{}) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"k8s.io/pod-security-admission/policy"
+)
+
+// junitDefaultSuite is the test suite name used for entries without a source.
+const junitDefaultSuite = "pod-security"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// writeJUnit writes a JUnit XML report with one test suite per source and one test case per entry.
+// Forbidden entries are reported as failures listing each violation and its field paths.
+func writeJUnit(w io.Writer, entries []Entry) error {
+	report := junitTestSuites{Name: junitDefaultSuite}
+	suiteIndexes := map[string]int{}
+
+	for i := range entries {
+		e := &entries[i]
+		suiteName := e.Object.Source
+		if suiteName == "" {
+			suiteName = junitDefaultSuite
+		}
+		suiteIndex, ok := suiteIndexes[suiteName]
+		if !ok {
+			suiteIndex = len(report.Suites)
+			suiteIndexes[suiteName] = suiteIndex
+			report.Suites = append(report.Suites, junitTestSuite{Name: suiteName})
+		}
+		suite := &report.Suites[suiteIndex]
+
+		testCase := junitTestCase{
+			ClassName: e.Policy.String(),
+			Name:      e.Object.String(),
+		}
+		if !e.Result.Allowed {
+			var content strings.Builder
+			for _, v := range e.Violations() {
+				content.WriteString(violationMessage(&v))
+				content.WriteString("\n")
+				for _, err := range v.Fields {
+					content.WriteString("  ")
+					// The bad value is included for forbidden fields.
+					// Example: spec.containers[0].securityContext.capabilities.add: Forbidden: ["NET_RAW"]
+					content.WriteString(err.Field + ": " + policy.FieldErrorBody(err))
+					content.WriteString("\n")
+				}
+			}
+			testCase.Failure = &junitFailure{
+				Message: failureMessage(e),
+				Type:    string(e.Policy.Level),
+				Content: content.String(),
+			}
+			suite.Failures++
+			report.Failures++
+		}
+		suite.Tests++
+		report.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+	FormatJUnit Format = "junit"
+)
+
+var validFormats = []string{
+	string(FormatText),
+	string(FormatJSON),
+	string(FormatSARIF),
+	string(FormatJUnit),
+}
+
+// ParseFormat returns the report format for the given name.
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatText, FormatJSON, FormatSARIF, FormatJUnit:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf(`must be one of %s`, strings.Join(validFormats, ", "))
+	}
+}
+
+// Object identifies the object whose pod spec was evaluated.
+type Object struct {
+	// Source is the file the object was read from, if any.
+	Source string
+	// Document is the zero-based index of the document within the source.
+	Document int
+	// APIVersion and Kind are the type of the object.
+	APIVersion string
+	Kind       string
+	// Namespace and Name identify the object.
+	Namespace string
+	Name      string
+}
+
+// String returns a compact representation of the object.
+// Example: Deployment default/web
+func (o *Object) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+// Entry is the result of evaluating the pod spec of a single object against a policy level and version.
+type Entry struct {
+	Object Object
+	Policy api.LevelVersion
+	Result policy.AggregateCheckResult
+	// Explanation optionally traces the evaluation. It is rendered by the text and JSON formats.
+	Explanation *policy.Explanation
+}
+
+// Violation is a single forbidden check of an Entry.
+type Violation struct {
+	// CheckID is the ID of the check that forbade the pod, if known.
+	CheckID policy.CheckID
+	// Level is the level of the check that forbade the pod, if known.
+	Level api.Level
+	// MinimumVersion is the minimum version of the check revision that forbade the pod, if known.
+	MinimumVersion api.Version
+	// Reason is the forbidden reason of the check.
+	Reason string
+	// Detail is the optional forbidden detail of the check.
+	Detail string
+	// Fields are the field errors reported by the check, if evaluated with field errors.
+	Fields field.ErrorList
+}
+
+// RuleID returns the identifier to group violations by, preferring the check ID over the reason.
+func (v *Violation) RuleID() string {
+	if v.CheckID != "" {
+		return string(v.CheckID)
+	}
+	return v.Reason
+}
+
+// Violations returns the forbidden checks of the entry, in the order they were evaluated.
+func (e *Entry) Violations() []Violation {
+	violations := make([]Violation, 0, len(e.Result.ForbiddenReasons))
+	for i, reason := range e.Result.ForbiddenReasons {
+		v := Violation{
+			Reason: reason,
+			Detail: e.Result.ForbiddenDetails[i],
+		}
+		if i < len(e.Result.ForbiddenResults) {
+			// attribute field errors to the individual check when available
+			r := e.Result.ForbiddenResults[i]
+			v.CheckID = r.CheckID
+			v.Level = r.Level
+			v.MinimumVersion = r.MinimumVersion
+			if r.ErrList != nil {
+				v.Fields = *r.ErrList
+			}
+		} else {
+			v.Fields = e.Result.ErrLists[reason]
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// Write renders the entries in the given format.
+func Write(w io.Writer, format Format, entries []Entry) error {
+	switch format {
+	case FormatText:
+		return writeText(w, entries)
+	case FormatJSON:
+		return writeJSON(w, entries)
+	case FormatSARIF:
+		return writeSARIF(w, entries)
+	case FormatJUnit:
+		return writeJUnit(w, entries)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// failureMessage returns the message for a forbidden entry.
+// Example: violates PodSecurity "restricted:latest": host ports (8080)
+func failureMessage(e *Entry) string {
+	return fmt.Sprintf("violates PodSecurity %q: %s", e.Policy.String(), e.Result.ForbiddenDetail())
+}
+
+// violationMessage returns the message for a single violation.
+// Example: host ports (8080)
+func violationMessage(v *Violation) string {
+	if v.Detail == "" {
+		return v.Reason
+	}
+	return fmt.Sprintf("%s (%s)", v.Reason, v.Detail)
+}
+
+// violationMessageWithPolicy returns the message for a single violation of the entry policy.
+// Example: violates PodSecurity "restricted:latest": host ports (8080)
+func violationMessageWithPolicy(e *Entry, v *Violation) string {
+	return fmt.Sprintf("violates PodSecurity %q: %s", e.Policy.String(), violationMessage(v))
+}
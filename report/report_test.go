@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"k8s.io/utils/ptr"
+)
+
+func testEntries() []Entry {
+	restricted := api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()}
+	return []Entry{
+		{
+			Object: Object{Source: "app.yaml", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "allowed"},
+			Policy: restricted,
+			Result: policy.AggregateCheckResults([]policy.CheckResult{{Allowed: true}}),
+		},
+		{
+			Object: Object{Source: "app.yaml", Document: 1, APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			Policy: restricted,
+			Result: policy.AggregateCheckResults([]policy.CheckResult{
+				{
+					CheckID:         "hostPorts",
+					Level:           api.LevelBaseline,
+					MinimumVersion:  api.MajorMinorVersion(1, 0),
+					Allowed:         false,
+					ForbiddenReason: "host ports",
+					ForbiddenDetail: "8080",
+					ErrList:         &field.ErrorList{field.Forbidden(field.NewPath("spec", "containers").Index(0).Child("ports").Index(0).Child("hostPort"), "")},
+				},
+				{
+					Allowed:         false,
+					ForbiddenReason: "runAsNonRoot != true",
+				},
+			}),
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []string{"text", "json", "sarif", "junit"} {
+		format, err := ParseFormat(f)
+		assert.NoError(t, err)
+		assert.Equal(t, Format(f), format)
+	}
+	_, err := ParseFormat("yaml")
+	assert.Error(t, err)
+}
+
+func TestWriteText(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatText, testEntries()))
+	assert.Equal(t, `app.yaml[1]: Deployment web: violates PodSecurity "restricted:latest": host ports (8080), runAsNonRoot != true`+"\n", b.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	entries := testEntries()
+	entries[1].Result.ErrLists["host ports"][0].BadValue = int32(8080)
+
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatJSON, entries))
+
+	// The first document of a source is distinguishable from objects without a source.
+	var actual jsonReport
+	require.NoError(t, json.Unmarshal(b.Bytes(), &actual))
+	expected := jsonReport{Results: []jsonResult{
+		{
+			Object:  jsonObject{Source: "app.yaml", Document: ptr.To(0), APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "allowed"},
+			Level:   "restricted",
+			Version: "latest",
+			Allowed: true,
+		},
+		{
+			Object:  jsonObject{Source: "app.yaml", Document: ptr.To(1), APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			Level:   "restricted",
+			Version: "latest",
+			Violations: []jsonViolation{
+				{Check: "hostPorts", Level: "baseline", MinimumVersion: "v1.0", Reason: "host ports", Detail: "8080", Fields: []jsonField{{Path: "spec.containers[0].ports[0].hostPort", Type: "FieldValueForbidden", Value: float64(8080)}}},
+				{Reason: "runAsNonRoot != true"},
+			},
+		},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func testExplanation() *policy.Explanation {
+	return &policy.Explanation{
+		Requested: api.LevelVersion{Level: api.LevelRestricted, Version: api.LatestVersion()},
+		Evaluated: api.LevelVersion{Level: api.LevelRestricted, Version: api.MajorMinorVersion(1, 32)},
+		Duration:  15 * time.Microsecond,
+		Checks: []policy.ExplainedCheck{
+			{
+				Result:   policy.CheckResult{Allowed: true, CheckID: "hostPorts", Level: api.LevelBaseline, MinimumVersion: api.MajorMinorVersion(1, 0)},
+				Duration: 2 * time.Microsecond,
+			},
+			{
+				Result: policy.CheckResult{
+					Allowed: false, ForbiddenReason: "restricted volume types", ForbiddenDetail: `volume "a" uses restricted volume type "hostPath"`,
+					CheckID: "restrictedVolumes", Level: api.LevelRestricted, MinimumVersion: api.MajorMinorVersion(1, 0),
+				},
+				Overrides: []policy.CheckID{"hostPathVolumes"},
+				Duration:  3 * time.Microsecond,
+			},
+		},
+	}
+}
+
+func TestWriteTextExplanation(t *testing.T) {
+	entries := testEntries()
+	entries[0].Explanation = testExplanation()
+
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatText, entries))
+	assert.Equal(t, `app.yaml[0]: Pod default/allowed: allowed by PodSecurity "restricted:latest"
+  evaluated "restricted:v1.32" in 15µs
+  pass hostPorts (baseline, v1.0) in 2µs
+  fail restrictedVolumes (restricted, v1.0, overrides hostPathVolumes) in 3µs: restricted volume types (volume "a" uses restricted volume type "hostPath")
+app.yaml[1]: Deployment web: violates PodSecurity "restricted:latest": host ports (8080), runAsNonRoot != true
+`, b.String())
+}
+
+func TestWriteJSONExplanation(t *testing.T) {
+	entries := testEntries()[:1]
+	entries[0].Explanation = testExplanation()
+
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatJSON, entries))
+
+	var actual jsonReport
+	require.NoError(t, json.Unmarshal(b.Bytes(), &actual))
+	require.Len(t, actual.Results, 1)
+	assert.Equal(t, &jsonExplanation{
+		Level:          "restricted",
+		Version:        "v1.32",
+		DurationMicros: 15,
+		Checks: []jsonExplainedCheck{
+			{Check: "hostPorts", Level: "baseline", MinimumVersion: "v1.0", Allowed: true, DurationMicros: 2},
+			{Check: "restrictedVolumes", Level: "restricted", MinimumVersion: "v1.0", Allowed: false, Overrides: []string{"hostPathVolumes"}, DurationMicros: 3},
+		},
+	}, actual.Results[0].Explanation)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatSARIF, testEntries()))
+
+	var actual sarifLog
+	require.NoError(t, json.Unmarshal(b.Bytes(), &actual))
+	assert.Equal(t, sarifVersion, actual.Version)
+	require.Len(t, actual.Runs, 1)
+	run := actual.Runs[0]
+	assert.Equal(t, []sarifRule{
+		{ID: "hostPorts", ShortDescription: sarifMessage{Text: "host ports"}},
+		{ID: "runAsNonRoot != true", ShortDescription: sarifMessage{Text: "runAsNonRoot != true"}},
+	}, run.Tool.Driver.Rules)
+	require.Len(t, run.Results, 2)
+	assert.Equal(t, "hostPorts", run.Results[0].RuleID)
+	assert.Equal(t, 0, run.Results[0].RuleIndex)
+	assert.Equal(t, `Deployment web violates PodSecurity "restricted:latest": host ports (8080)`, run.Results[0].Message.Text)
+	assert.Equal(t, []sarifLocation{{
+		PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "app.yaml"}},
+		LogicalLocations: []sarifLogicalLocation{
+			{FullyQualifiedName: "Deployment web", Kind: "object"},
+			{FullyQualifiedName: "spec.containers[0].ports[0].hostPort", Kind: "member"},
+		},
+	}}, run.Results[0].Locations)
+	assert.Equal(t, 1, run.Results[1].RuleIndex)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, FormatJUnit, testEntries()))
+
+	var actual junitTestSuites
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &actual))
+	assert.Equal(t, 2, actual.Tests)
+	assert.Equal(t, 1, actual.Failures)
+	require.Len(t, actual.Suites, 1)
+	suite := actual.Suites[0]
+	assert.Equal(t, "app.yaml", suite.Name)
+	require.Len(t, suite.TestCases, 2)
+	assert.Nil(t, suite.TestCases[0].Failure)
+	assert.Equal(t, "Pod default/allowed", suite.TestCases[0].Name)
+	require.NotNil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, `violates PodSecurity "restricted:latest": host ports (8080), runAsNonRoot != true`, suite.TestCases[1].Failure.Message)
+	assert.Equal(t, "host ports (8080)\n  spec.containers[0].ports[0].hostPort: Forbidden\nrunAsNonRoot != true\n", suite.TestCases[1].Failure.Content)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	sarifToolName = "pod-security-admission"
+	sarifToolURI  = "https://kubernetes.io/docs/concepts/security/pod-security-standards/"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// writeSARIF writes a SARIF 2.1.0 log with one result per violation. Allowed entries are omitted.
+// Each violation is located in the source file of the object, and at the object and field paths
+// of its field errors as logical locations, since source line numbers are not known.
+func writeSARIF(w io.Writer, entries []Entry) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           sarifToolName,
+			InformationURI: sarifToolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndexes := map[string]int{}
+
+	for i := range entries {
+		e := &entries[i]
+		for _, v := range e.Violations() {
+			ruleID := v.RuleID()
+			ruleIndex, ok := ruleIndexes[ruleID]
+			if !ok {
+				ruleIndex = len(run.Tool.Driver.Rules)
+				ruleIndexes[ruleID] = ruleIndex
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               ruleID,
+					ShortDescription: sarifMessage{Text: v.Reason},
+				})
+			}
+
+			location := sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: e.Object.String(), Kind: "object"}},
+			}
+			if e.Object.Source != "" {
+				location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: e.Object.Source}}
+			}
+			for _, err := range v.Fields {
+				location.LogicalLocations = append(location.LogicalLocations, sarifLogicalLocation{FullyQualifiedName: err.Field, Kind: "member"})
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID,
+				RuleIndex: ruleIndex,
+				Level:     "error",
+				Message:   sarifMessage{Text: e.Object.String() + " " + violationMessageWithPolicy(e, &v)},
+				Locations: []sarifLocation{location},
+				Properties: map[string]string{
+					"level":   string(e.Policy.Level),
+					"version": e.Policy.Version.String(),
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
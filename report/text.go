@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/pod-security-admission/policy"
+)
+
+// writeText writes one line per forbidden entry. Allowed entries are omitted, unless they are explained.
+// Example: deploy.yaml[0]: Deployment default/web: violates PodSecurity "restricted:latest": host ports (8080)
+//
+// Explained entries are followed by one indented line per evaluated check.
+// Example:
+//
+//	deploy.yaml[0]: Deployment default/web: violates PodSecurity "restricted:latest": restricted volume types (...)
+//	  evaluated "restricted:v1.32" in 85µs
+//	  pass hostNamespaces (baseline, v1.0) in 2µs
+//	  fail restrictedVolumes (restricted, v1.0, overrides hostPathVolumes) in 3µs: restricted volume types (...)
+func writeText(w io.Writer, entries []Entry) error {
+	for i := range entries {
+		e := &entries[i]
+		if e.Result.Allowed && e.Explanation == nil {
+			continue
+		}
+		var prefix string
+		if e.Object.Source != "" {
+			prefix = fmt.Sprintf("%s[%d]: ", e.Object.Source, e.Object.Document)
+		}
+		message := failureMessage(e)
+		if e.Result.Allowed {
+			message = fmt.Sprintf("allowed by PodSecurity %q", e.Policy.String())
+		}
+		if _, err := fmt.Fprintf(w, "%s%s: %s\n", prefix, e.Object.String(), message); err != nil {
+			return err
+		}
+		if e.Explanation != nil {
+			if err := writeTextExplanation(w, e.Explanation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTextExplanation(w io.Writer, explanation *policy.Explanation) error {
+	if _, err := fmt.Fprintf(w, "  evaluated %q in %s\n", explanation.Evaluated.String(), explanation.Duration); err != nil {
+		return err
+	}
+	for _, c := range explanation.Checks {
+		outcome := "pass"
+		if !c.Result.Allowed {
+			outcome = "fail"
+		}
+		attributes := []string{string(c.Result.Level), c.Result.MinimumVersion.String()}
+		if len(c.Overrides) > 0 {
+			overrides := make([]string, 0, len(c.Overrides))
+			for _, id := range c.Overrides {
+				overrides = append(overrides, string(id))
+			}
+			attributes = append(attributes, "overrides "+strings.Join(overrides, ", "))
+		}
+		line := fmt.Sprintf("  %s %s (%s) in %s", outcome, c.Result.CheckID, strings.Join(attributes, ", "), c.Duration)
+		if !c.Result.Allowed {
+			line += ": " + violationMessage(&Violation{Reason: c.Result.ForbiddenReason, Detail: c.Result.ForbiddenDetail})
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}